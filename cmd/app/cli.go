@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/shimeoki/mlat/internal/expr"
+	"github.com/shimeoki/mlat/internal/matrix"
+)
+
+// repeated -var NAME=PATH flags
+type variableFlags map[string]string
+
+func (p variableFlags) String() string {
+	pairs := make([]string, 0, len(p))
+	for name, path := range p {
+		pairs = append(pairs, name+"="+path)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (p variableFlags) Set(s string) error {
+	name, path, ok := strings.Cut(s, "=")
+	if !ok || name == "" || path == "" {
+		return errors.New("variable should be in the form NAME=PATH")
+	}
+
+	p[name] = path
+	return nil
+}
+
+func readFile(path string) (*matrix.Matrix, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return matrix.ReadFile(path, file)
+}
+
+func evaluate(w io.Writer, input string, paths variableFlags) error {
+	vars := make(map[string]*matrix.Matrix, len(paths))
+	for name, path := range paths {
+		m, err := readFile(path)
+		if err != nil {
+			return fmt.Errorf("variable %s: %w", name, err)
+		}
+		vars[name] = m
+	}
+
+	value, err := expr.Evaluate(input, vars)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(w, strings.TrimSuffix(value.String(), "\n"))
+	return err
+}
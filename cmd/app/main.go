@@ -1,10 +1,27 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"os"
+
 	"github.com/shimeoki/mlat/internal/ui"
 )
 
 func main() {
+	vars := variableFlags{}
+	expression := flag.String("eval", "", "evaluate expression and print the result instead of starting the GUI")
+	flag.Var(vars, "var", "matrix variable for -eval in the form NAME=PATH, can be repeated")
+	flag.Parse()
+
+	if *expression != "" {
+		if err := evaluate(os.Stdout, *expression, vars); err != nil {
+			fmt.Fprintln(os.Stderr, "mlat:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	gui := ui.NewGUI()
 	gui.Run()
 }
@@ -0,0 +1,383 @@
+package expr
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/shimeoki/mlat/internal/matrix"
+)
+
+// result of the evaluation. Matrix is nil for scalars
+type Value struct {
+	Matrix *matrix.Matrix
+	Scalar float64
+
+	// identity matrix of unknown size multiplied by Scalar.
+	// size is taken from the matrix it is combined with
+	identity bool
+}
+
+func scalarValue(scalar float64) *Value {
+	return &Value{Scalar: scalar}
+}
+
+func identityValue(scalar float64) *Value {
+	return &Value{Scalar: scalar, identity: true}
+}
+
+func matrixValue(data [][]float64) (*Value, error) {
+	m, err := matrix.NewMatrix(data, false)
+	if err != nil {
+		return nil, err
+	}
+	return &Value{Matrix: m}, nil
+}
+
+func (v *Value) IsScalar() bool {
+	return v.Matrix == nil && !v.identity
+}
+
+func (v *Value) isMatrix() bool {
+	return v.Matrix != nil
+}
+
+func (v *Value) String() string {
+	if v.isMatrix() {
+		return matrix.MatrixToString(v.Matrix.Data, " ")
+	}
+	if v.identity {
+		return fmt.Sprintf("%v*I", v.Scalar)
+	}
+	return fmt.Sprint(v.Scalar)
+}
+
+func describe(v *Value) string {
+	switch {
+	case v.isMatrix():
+		return fmt.Sprintf("%dx%d matrix", v.Matrix.Rows, v.Matrix.Cols)
+	case v.identity:
+		return "identity matrix"
+	default:
+		return "scalar"
+	}
+}
+
+// returns Scalar*I with the size of a square matrix
+func identityLike(v *Value, size int) [][]float64 {
+	data, memory, _ := matrix.Malloc[float64](size, size)
+	for i := range size {
+		data[i] = memory[i*size : (i+1)*size]
+		data[i][i] = v.Scalar
+	}
+	return data
+}
+
+// evaluates expression with named variables.
+// identifier I that is not a variable is an identity matrix of the fitting size
+func (e *Expression) Evaluate(vars map[string]*matrix.Matrix) (*Value, error) {
+	value, err := eval(e.root, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	if value.identity {
+		return nil, errorf(e.root.position(), "size of identity matrix I cannot be determined")
+	}
+
+	return value, nil
+}
+
+// parses and evaluates expression
+func Evaluate(input string, vars map[string]*matrix.Matrix) (*Value, error) {
+	e, err := Parse(input)
+	if err != nil {
+		return nil, err
+	}
+	return e.Evaluate(vars)
+}
+
+func eval(n node, vars map[string]*matrix.Matrix) (*Value, error) {
+	switch n := n.(type) {
+	case *numberNode:
+		return scalarValue(n.value), nil
+	case *variableNode:
+		m, ok := vars[n.name]
+		if !ok {
+			if n.name == "I" {
+				return identityValue(1), nil
+			}
+			return nil, errorf(n.pos, "unknown variable %q", n.name)
+		}
+		// variables are copied, so augmented flag and cached determinants are not used
+		return matrixValue(m.Data)
+	case *negateNode:
+		operand, err := eval(n.operand, vars)
+		if err != nil {
+			return nil, err
+		}
+		return scale(operand, -1), nil
+	case *transposeNode:
+		operand, err := eval(n.operand, vars)
+		if err != nil {
+			return nil, err
+		}
+		return transpose(operand)
+	case *binaryNode:
+		left, err := eval(n.left, vars)
+		if err != nil {
+			return nil, err
+		}
+		right, err := eval(n.right, vars)
+		if err != nil {
+			return nil, err
+		}
+		return binary(n, left, right)
+	case *callNode:
+		return call(n, vars)
+	default:
+		return nil, errorf(n.position(), "unknown expression")
+	}
+}
+
+func scale(v *Value, scalar float64) *Value {
+	switch {
+	case v.isMatrix():
+		result, _ := matrixValue(v.Matrix.Scale(scalar))
+		return result
+	case v.identity:
+		return identityValue(v.Scalar * scalar)
+	default:
+		return scalarValue(v.Scalar * scalar)
+	}
+}
+
+func transpose(v *Value) (*Value, error) {
+	if !v.isMatrix() {
+		return v, nil
+	}
+	return matrixValue(v.Matrix.GetTranspose())
+}
+
+func binary(n *binaryNode, left, right *Value) (*Value, error) {
+	switch n.op {
+	case tokenPlus, tokenMinus:
+		return add(n, left, right)
+	case tokenStar:
+		return multiply(n, left, right)
+	case tokenSlash:
+		if !right.IsScalar() {
+			return nil, errorf(n.pos, "cannot divide by %s, use inv()", describe(right))
+		}
+		if right.Scalar == 0 {
+			return nil, errorf(n.pos, "division by zero")
+		}
+		return scale(left, 1/right.Scalar), nil
+	case tokenCaret:
+		return power(n, left, right)
+	default:
+		return nil, errorf(n.pos, "unknown operator %q", n.text)
+	}
+}
+
+func add(n *binaryNode, left, right *Value) (*Value, error) {
+	if n.op == tokenMinus {
+		right = scale(right, -1)
+	}
+
+	switch {
+	case left.IsScalar() && right.IsScalar():
+		return scalarValue(left.Scalar + right.Scalar), nil
+	case left.identity && right.identity:
+		return identityValue(left.Scalar + right.Scalar), nil
+	case left.isMatrix() && right.isMatrix():
+		data, err := left.Matrix.Add(right.Matrix)
+		if err != nil {
+			return nil, errorf(n.pos, "dimension mismatch in '%s': %s", n.text, err)
+		}
+		return matrixValue(data)
+	case left.isMatrix() && right.identity, left.identity && right.isMatrix():
+		m, identity := left, right
+		if right.isMatrix() {
+			m, identity = right, left
+		}
+		if m.Matrix.Rows != m.Matrix.Cols {
+			return nil, errorf(
+				n.pos, "dimension mismatch in '%s': identity matrix with %s",
+				n.text, describe(m),
+			)
+		}
+
+		other, _ := matrix.NewMatrix(identityLike(identity, m.Matrix.Rows), false)
+		data, _ := m.Matrix.Add(other)
+		return matrixValue(data)
+	default:
+		return nil, errorf(n.pos, "cannot apply '%s' to %s and %s", n.text, describe(left), describe(right))
+	}
+}
+
+func multiply(n *binaryNode, left, right *Value) (*Value, error) {
+	switch {
+	case left.IsScalar() || left.identity:
+		return scale(right, left.Scalar), nil
+	case right.IsScalar() || right.identity:
+		return scale(left, right.Scalar), nil
+	}
+
+	if left.Matrix.Cols != right.Matrix.Rows {
+		return nil, errorf(
+			n.pos, "dimension mismatch in '*': %s and %s",
+			describe(left), describe(right),
+		)
+	}
+
+	return matrixValue(left.Matrix.Multiply(right.Matrix))
+}
+
+// larger exponents of matrices overflow or vanish anyway
+const maxMatrixExponent = 1 << 20
+
+func power(n *binaryNode, base, exponent *Value) (*Value, error) {
+	if !exponent.IsScalar() {
+		return nil, errorf(n.pos, "exponent should be a scalar, got %s", describe(exponent))
+	}
+
+	if base.IsScalar() {
+		return scalarValue(math.Pow(base.Scalar, exponent.Scalar)), nil
+	}
+
+	k := exponent.Scalar
+	if k != math.Trunc(k) {
+		return nil, errorf(n.pos, "exponent of a matrix should be an integer, got %v", k)
+	}
+
+	if base.identity {
+		return identityValue(math.Pow(base.Scalar, k)), nil
+	}
+
+	if base.Matrix.Rows != base.Matrix.Cols {
+		return nil, errorf(n.pos, "cannot raise %s to a power", describe(base))
+	}
+
+	if math.Abs(k) > maxMatrixExponent {
+		return nil, errorf(n.pos, "exponent of a matrix should be at most %d in absolute value, got %v", maxMatrixExponent, k)
+	}
+
+	e := int(k)
+	if e < 0 {
+		inverse, err := inverse(n.pos, base)
+		if err != nil {
+			return nil, err
+		}
+		base, e = inverse, -e
+	}
+
+	// binary exponentiation, square holds base^(2^i)
+	result, _ := matrixValue(identityLike(identityValue(1), base.Matrix.Rows))
+	square := base
+	for ; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			result, _ = matrixValue(result.Matrix.Multiply(square.Matrix))
+		}
+		if e > 1 {
+			square, _ = matrixValue(square.Matrix.Multiply(square.Matrix))
+		}
+	}
+
+	return result, nil
+}
+
+func inverse(pos int, v *Value) (*Value, error) {
+	switch {
+	case v.IsScalar() || v.identity:
+		if v.Scalar == 0 {
+			return nil, errorf(pos, "division by zero")
+		}
+		if v.identity {
+			return identityValue(1 / v.Scalar), nil
+		}
+		return scalarValue(1 / v.Scalar), nil
+	case v.Matrix.Rows != v.Matrix.Cols:
+		return nil, errorf(pos, "cannot invert %s", describe(v))
+	}
+
+	data := v.Matrix.GetInverse()
+	if data == nil {
+		return nil, errorf(pos, "matrix is singular")
+	}
+
+	return matrixValue(data)
+}
+
+type function struct {
+	args int
+	call func(pos int, args []*Value) (*Value, error)
+}
+
+var functions map[string]function
+
+func init() {
+	functions = map[string]function{
+		"inv": {1, func(pos int, args []*Value) (*Value, error) {
+			return inverse(pos, args[0])
+		}},
+		"T": {1, func(pos int, args []*Value) (*Value, error) {
+			return transpose(args[0])
+		}},
+		"det": {1, func(pos int, args []*Value) (*Value, error) {
+			v := args[0]
+			switch {
+			case v.IsScalar():
+				return v, nil
+			case v.identity:
+				return nil, errorf(pos, "size of identity matrix I cannot be determined")
+			}
+
+			dets, err := v.Matrix.Calculate()
+			if err != nil {
+				return nil, errorf(pos, "cannot calculate determinant of %s", describe(v))
+			}
+			return scalarValue(dets[0]), nil
+		}},
+		"tr": {1, func(pos int, args []*Value) (*Value, error) {
+			v := args[0]
+			if !v.isMatrix() || v.Matrix.Rows != v.Matrix.Cols {
+				return nil, errorf(pos, "cannot calculate trace of %s", describe(v))
+			}
+
+			trace := 0.0
+			for i := range v.Matrix.Rows {
+				trace += v.Matrix.Data[i][i]
+			}
+			return scalarValue(trace), nil
+		}},
+		"I": {1, func(pos int, args []*Value) (*Value, error) {
+			size := args[0].Scalar
+			if !args[0].IsScalar() || size != math.Trunc(size) || size <= 0 {
+				return nil, errorf(pos, "size of identity matrix should be a positive integer")
+			}
+			return matrixValue(identityLike(identityValue(1), int(size)))
+		}},
+	}
+}
+
+func call(n *callNode, vars map[string]*matrix.Matrix) (*Value, error) {
+	f, ok := functions[n.name]
+	if !ok {
+		return nil, errorf(n.pos, "unknown function %q", n.name)
+	}
+
+	if len(n.args) != f.args {
+		return nil, errorf(n.pos, "function %s expects %d argument(s), got %d", n.name, f.args, len(n.args))
+	}
+
+	args := make([]*Value, len(n.args))
+	for i, arg := range n.args {
+		value, err := eval(arg, vars)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = value
+	}
+
+	return f.call(n.pos, args)
+}
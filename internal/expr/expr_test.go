@@ -0,0 +1,103 @@
+package expr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shimeoki/mlat/internal/matrix"
+)
+
+func variables() map[string]*matrix.Matrix {
+	a, _ := matrix.NewMatrix([][]float64{{2, 0}, {0, 4}}, false)
+	b, _ := matrix.NewMatrix([][]float64{{1, 2}, {3, 4}}, false)
+	c, _ := matrix.NewMatrix([][]float64{{1, 2, 3}, {4, 5, 6}}, false)
+	v, _ := matrix.NewMatrix([][]float64{{1}, {1}}, false)
+
+	return map[string]*matrix.Matrix{"A": a, "B": b, "C": c, "v": v}
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"scalar arithmetic", "1 + 2 * 3 - 4 / 2", "5"},
+		{"unary minus and power", "-2^2 + (1 - 3)^2", "0"},
+		{"determinant", "det(A) + det(B)", "6"},
+		{"trace", "tr(B)", "5"},
+		{"sum", "A + B", "3 2\n3 8\n"},
+		{"product", "A * B", "2 4\n12 16\n"},
+		{"transpose function", "T(C)", "1 4\n2 5\n3 6\n"},
+		{"transpose postfix", "v' * B", "4 6\n"},
+		{"inverse", "inv(A) * B", "0.5 1\n0.75 1\n"},
+		{"identity", "B - I", "0 2\n3 3\n"},
+		{"scaled identity", "inv(A) * B + 2*T(B) - det(A)*I", "-5.5 7\n4.75 1\n"},
+		{"identity function", "I(2) * 3", "3 0\n0 3\n"},
+		{"matrix power", "B^2", "7 10\n15 22\n"},
+		{"negative power", "A^-1", "0.5 0\n0 0.25\n"},
+		{"zero power", "B^0", "1 0\n0 1\n"},
+		{"odd power", "B^5", "1069 1558\n2337 3406\n"},
+		{"large power", "(v' * v / 2)^1000000", "1\n"},
+		{"identity power", "I^1e20 * A", "2 0\n0 4\n"},
+		{"division by scalar", "A / 2", "1 0\n0 2\n"},
+		{"1x1 inverse", "inv(v' * v)", "0.5\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				value, err := Evaluate(test.input, variables())
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if ans := value.String(); ans != test.want {
+					t.Errorf("\ngot:\n%s\nwant:\n%s", ans, test.want)
+				}
+			})
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		pos   int
+		msg   string
+	}{
+		{"unknown character", "A $ B", 3, `unexpected character '$'`},
+		{"unclosed paren", "(A + B", 7, `expected ')', got "end of expression"`},
+		{"trailing token", "A B", 3, `unexpected "B"`},
+		{"empty", "", 1, "unexpected end of expression"},
+		{"unknown variable", "A + X", 5, `unknown variable "X"`},
+		{"unknown function", "foo(A)", 1, `unknown function "foo"`},
+		{"arguments", "inv(A, B)", 1, "function inv expects 1 argument(s), got 2"},
+		{"product mismatch", "A * C * A", 7, "dimension mismatch in '*': 2x3 matrix and 2x2 matrix"},
+		{"sum mismatch", "A + C", 3, "dimension mismatch in '+': dimensions 2x2 and 2x3 do not match"},
+		{"scalar and matrix", "1 + A", 3, "cannot apply '+' to scalar and 2x2 matrix"},
+		{"identity with rectangle", "C - I", 3, "dimension mismatch in '-': identity matrix with 2x3 matrix"},
+		{"lone identity", "2 * I", 3, "size of identity matrix I cannot be determined"},
+		{"singular", "inv(A - 2*I)", 1, "matrix is singular"},
+		{"non-square inverse", "inv(C)", 1, "cannot invert 2x3 matrix"},
+		{"divide by matrix", "A / B", 3, "cannot divide by 2x2 matrix, use inv()"},
+		{"division by zero", "A / (1 - 1)", 3, "division by zero"},
+		{"fractional power", "B^0.5", 2, "exponent of a matrix should be an integer, got 0.5"},
+		{"huge power", "B^1e20", 2, "exponent of a matrix should be at most 1048576 in absolute value, got 1e+20"},
+		{"huge negative power", "A^-2e6", 2, "exponent of a matrix should be at most 1048576 in absolute value, got -2e+06"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				_, err := Evaluate(test.input, variables())
+
+				var exprErr *Error
+				if !errors.As(err, &exprErr) {
+					t.Fatalf("expected *Error, got %v", err)
+				}
+				if exprErr.Pos != test.pos || exprErr.Msg != test.msg {
+					t.Errorf("\ngot:  %d %s\nwant: %d %s", exprErr.Pos, exprErr.Msg, test.pos, test.msg)
+				}
+			})
+	}
+}
@@ -0,0 +1,231 @@
+package expr
+
+type node interface {
+	position() int
+}
+
+type numberNode struct {
+	pos   int
+	value float64
+}
+
+type variableNode struct {
+	pos  int
+	name string
+}
+
+type negateNode struct {
+	pos     int
+	operand node
+}
+
+type binaryNode struct {
+	pos         int
+	op          tokenKind
+	text        string
+	left, right node
+}
+
+type transposeNode struct {
+	pos     int
+	operand node
+}
+
+type callNode struct {
+	pos  int
+	name string
+	args []node
+}
+
+func (n *numberNode) position() int    { return n.pos }
+func (n *variableNode) position() int  { return n.pos }
+func (n *negateNode) position() int    { return n.pos }
+func (n *binaryNode) position() int    { return n.pos }
+func (n *transposeNode) position() int { return n.pos }
+func (n *callNode) position() int      { return n.pos }
+
+// recursive descent parser for the grammar
+//
+//	expr    = term { ("+" | "-") term }
+//	term    = unary { ("*" | "/") unary }
+//	unary   = "-" unary | power
+//	power   = postfix [ "^" unary ]
+//	postfix = primary { "'" }
+//	primary = number | ident [ "(" expr { "," expr } ")" ] | "(" expr ")"
+type parser struct {
+	tokens  []token
+	current int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.current]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.current]
+	if t.kind != tokenEOF {
+		p.current++
+	}
+	return t
+}
+
+func (p *parser) expect(kind tokenKind, what string) (token, error) {
+	t := p.next()
+	if t.kind != kind {
+		return t, errorf(t.pos, "expected %s, got %q", what, t.text)
+	}
+	return t, nil
+}
+
+func (p *parser) parseExpr() (node, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokenPlus || p.peek().kind == tokenMinus {
+		op := p.next()
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{pos: op.pos, op: op.kind, text: op.text, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseTerm() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokenStar || p.peek().kind == tokenSlash {
+		op := p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{pos: op.pos, op: op.kind, text: op.text, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.peek().kind == tokenMinus {
+		op := p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &negateNode{pos: op.pos, operand: operand}, nil
+	}
+
+	return p.parsePower()
+}
+
+func (p *parser) parsePower() (node, error) {
+	base, err := p.parsePostfix()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek().kind != tokenCaret {
+		return base, nil
+	}
+
+	op := p.next()
+	exponent, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	return &binaryNode{pos: op.pos, op: op.kind, text: op.text, left: base, right: exponent}, nil
+}
+
+func (p *parser) parsePostfix() (node, error) {
+	operand, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokenQuote {
+		op := p.next()
+		operand = &transposeNode{pos: op.pos, operand: operand}
+	}
+
+	return operand, nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+
+	switch t.kind {
+	case tokenNumber:
+		return &numberNode{pos: t.pos, value: t.value}, nil
+	case tokenIdent:
+		if p.peek().kind != tokenLParen {
+			return &variableNode{pos: t.pos, name: t.text}, nil
+		}
+
+		p.next()
+		call := &callNode{pos: t.pos, name: t.text}
+		for {
+			arg, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			call.args = append(call.args, arg)
+
+			if p.peek().kind != tokenComma {
+				break
+			}
+			p.next()
+		}
+
+		if _, err := p.expect(tokenRParen, "')'"); err != nil {
+			return nil, err
+		}
+		return call, nil
+	case tokenLParen:
+		inner, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := p.expect(tokenRParen, "')'"); err != nil {
+			return nil, err
+		}
+		return inner, nil
+	case tokenEOF:
+		return nil, errorf(t.pos, "unexpected end of expression")
+	default:
+		return nil, errorf(t.pos, "unexpected %q", t.text)
+	}
+}
+
+type Expression struct {
+	Source string
+	root   node
+}
+
+func Parse(input string) (*Expression, error) {
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	root, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, errorf(t.pos, "unexpected %q", t.text)
+	}
+
+	return &Expression{Source: input, root: root}, nil
+}
@@ -0,0 +1,469 @@
+package graph
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/shimeoki/mlat/internal/matrix"
+)
+
+// graph given by adjacency or weight matrix. zero value outside
+// the diagonal means there is no edge, diagonal is ignored.
+// vertices are counted from 0
+type Graph struct {
+	Size    int
+	Weights [][]float64
+}
+
+func New(m *matrix.Matrix) (*Graph, error) {
+	if m.Rows != m.Cols {
+		return nil, errors.New("matrix is not a square")
+	}
+
+	weights, memory, _ := matrix.Malloc[float64](m.Rows, m.Cols)
+	for i := range m.Rows {
+		weights[i] = memory[i*m.Cols : (i+1)*m.Cols]
+		copy(weights[i], m.Data[i])
+		weights[i][i] = 0
+	}
+
+	return &Graph{Size: m.Rows, Weights: weights}, nil
+}
+
+func (g *Graph) HasEdge(u, v int) bool {
+	return u != v && g.Weights[u][v] != 0
+}
+
+// graph is directed if its matrix is not symmetric
+func (g *Graph) Directed() bool {
+	for u := range g.Size {
+		for v := u + 1; v < g.Size; v++ {
+			if g.Weights[u][v] != g.Weights[v][u] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (g *Graph) checkVertex(v int) error {
+	if v < 0 || v >= g.Size {
+		return fmt.Errorf("vertex %d is out of range [0, %d)", v, g.Size)
+	}
+	return nil
+}
+
+// returns vertices in the order of breadth-first search
+func (g *Graph) BFS(start int) ([]int, error) {
+	if err := g.checkVertex(start); err != nil {
+		return nil, err
+	}
+
+	visited := make([]bool, g.Size)
+	visited[start] = true
+	order := []int{start}
+
+	for i := 0; i < len(order); i++ {
+		u := order[i]
+		for v := range g.Size {
+			if g.HasEdge(u, v) && !visited[v] {
+				visited[v] = true
+				order = append(order, v)
+			}
+		}
+	}
+
+	return order, nil
+}
+
+// returns vertices in the order of depth-first search
+func (g *Graph) DFS(start int) ([]int, error) {
+	if err := g.checkVertex(start); err != nil {
+		return nil, err
+	}
+
+	visited := make([]bool, g.Size)
+	var order []int
+
+	var visit func(u int)
+	visit = func(u int) {
+		visited[u] = true
+		order = append(order, u)
+		for v := range g.Size {
+			if g.HasEdge(u, v) && !visited[v] {
+				visit(v)
+			}
+		}
+	}
+	visit(start)
+
+	return order, nil
+}
+
+// returns connected components. direction of edges is ignored
+func (g *Graph) Components() [][]int {
+	component := make([]int, g.Size)
+	for i := range component {
+		component[i] = -1
+	}
+
+	var components [][]int
+	for start := range g.Size {
+		if component[start] != -1 {
+			continue
+		}
+
+		id := len(components)
+		component[start] = id
+		queue := []int{start}
+		for i := 0; i < len(queue); i++ {
+			u := queue[i]
+			for v := range g.Size {
+				if (g.HasEdge(u, v) || g.HasEdge(v, u)) && component[v] == -1 {
+					component[v] = id
+					queue = append(queue, v)
+				}
+			}
+		}
+
+		slices.Sort(queue)
+		components = append(components, queue)
+	}
+
+	return components
+}
+
+// returns strongly connected components found with Tarjan's algorithm.
+// components are sorted by their smallest vertex
+func (g *Graph) StronglyConnectedComponents() [][]int {
+	index := make([]int, g.Size)
+	low := make([]int, g.Size)
+	onStack := make([]bool, g.Size)
+	for i := range index {
+		index[i] = -1
+	}
+
+	var stack []int
+	var components [][]int
+	counter := 0
+
+	var connect func(u int)
+	connect = func(u int) {
+		index[u], low[u] = counter, counter
+		counter++
+		stack = append(stack, u)
+		onStack[u] = true
+
+		for v := range g.Size {
+			if !g.HasEdge(u, v) {
+				continue
+			}
+			if index[v] == -1 {
+				connect(v)
+				low[u] = min(low[u], low[v])
+			} else if onStack[v] {
+				low[u] = min(low[u], index[v])
+			}
+		}
+
+		if low[u] != index[u] {
+			return
+		}
+
+		var component []int
+		for {
+			v := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[v] = false
+			component = append(component, v)
+			if v == u {
+				break
+			}
+		}
+		slices.Sort(component)
+		components = append(components, component)
+	}
+
+	for u := range g.Size {
+		if index[u] == -1 {
+			connect(u)
+		}
+	}
+
+	slices.SortFunc(components, func(a, b []int) int { return a[0] - b[0] })
+	return components
+}
+
+// shortest distances between all pairs of vertices
+type Paths struct {
+	// math.Inf(1) if the vertex is unreachable
+	Dist [][]float64
+	next [][]int
+}
+
+// returns vertices of the shortest path from u to v, nil if v is unreachable
+func (p *Paths) Path(u, v int) []int {
+	if p.next[u][v] == -1 {
+		return nil
+	}
+
+	path := []int{u}
+	for u != v {
+		u = p.next[u][v]
+		path = append(path, u)
+	}
+	return path
+}
+
+// returns shortest paths between all pairs. weights can be negative,
+// but negative cycles are reported as an error
+func (g *Graph) FloydWarshall() (*Paths, error) {
+	n := g.Size
+
+	dist, memory, _ := matrix.Malloc[float64](n, n)
+	next, nextMemory, _ := matrix.Malloc[int](n, n)
+	for u := range n {
+		dist[u] = memory[u*n : (u+1)*n]
+		next[u] = nextMemory[u*n : (u+1)*n]
+		for v := range n {
+			switch {
+			case u == v:
+				dist[u][v], next[u][v] = 0, v
+			case g.HasEdge(u, v):
+				dist[u][v], next[u][v] = g.Weights[u][v], v
+			default:
+				dist[u][v], next[u][v] = math.Inf(1), -1
+			}
+		}
+	}
+
+	for k := range n {
+		for u := range n {
+			if math.IsInf(dist[u][k], 1) {
+				continue
+			}
+			for v := range n {
+				if dist[u][k]+dist[k][v] < dist[u][v] {
+					dist[u][v] = dist[u][k] + dist[k][v]
+					next[u][v] = next[u][k]
+				}
+			}
+		}
+	}
+
+	for u := range n {
+		if dist[u][u] < 0 {
+			return nil, fmt.Errorf("graph has a negative cycle through vertex %d", u)
+		}
+	}
+
+	return &Paths{Dist: dist, next: next}, nil
+}
+
+// shortest distances from one vertex
+type SingleSourcePaths struct {
+	Source int
+	// math.Inf(1) if the vertex is unreachable
+	Dist []float64
+	prev []int
+}
+
+// returns vertices of the shortest path from the source to v, nil if v is unreachable
+func (p *SingleSourcePaths) Path(v int) []int {
+	if math.IsInf(p.Dist[v], 1) {
+		return nil
+	}
+
+	path := []int{v}
+	for v != p.Source {
+		v = p.prev[v]
+		path = append(path, v)
+	}
+	slices.Reverse(path)
+	return path
+}
+
+type item struct {
+	vertex int
+	dist   float64
+}
+
+type queue []item
+
+func (q queue) Len() int           { return len(q) }
+func (q queue) Less(i, j int) bool { return q[i].dist < q[j].dist }
+func (q queue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *queue) Push(x any)        { *q = append(*q, x.(item)) }
+func (q *queue) Pop() any {
+	old := *q
+	x := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return x
+}
+
+// returns shortest paths from the source. weights should not be negative
+func (g *Graph) Dijkstra(source int) (*SingleSourcePaths, error) {
+	if err := g.checkVertex(source); err != nil {
+		return nil, err
+	}
+
+	for u := range g.Size {
+		for v := range g.Size {
+			if g.HasEdge(u, v) && g.Weights[u][v] < 0 {
+				return nil, fmt.Errorf("edge (%d, %d) has negative weight", u, v)
+			}
+		}
+	}
+
+	dist := make([]float64, g.Size)
+	prev := make([]int, g.Size)
+	for v := range dist {
+		dist[v], prev[v] = math.Inf(1), -1
+	}
+	dist[source] = 0
+
+	q := &queue{{source, 0}}
+	for q.Len() > 0 {
+		current := heap.Pop(q).(item)
+		u := current.vertex
+		if current.dist > dist[u] {
+			continue
+		}
+
+		for v := range g.Size {
+			if !g.HasEdge(u, v) {
+				continue
+			}
+			if d := dist[u] + g.Weights[u][v]; d < dist[v] {
+				dist[v], prev[v] = d, u
+				heap.Push(q, item{v, d})
+			}
+		}
+	}
+
+	return &SingleSourcePaths{Source: source, Dist: dist, prev: prev}, nil
+}
+
+// returns edges of the minimum spanning tree found with Prim's algorithm and its weight.
+// graph should be undirected and connected
+func (g *Graph) MinimumSpanningTree() ([][2]int, float64, error) {
+	if g.Directed() {
+		return nil, 0, errors.New("graph is directed")
+	}
+
+	inTree := make([]bool, g.Size)
+	cost := make([]float64, g.Size)
+	parent := make([]int, g.Size)
+	for v := range cost {
+		cost[v], parent[v] = math.Inf(1), -1
+	}
+	cost[0] = 0
+
+	var edges [][2]int
+	total := 0.0
+	for range g.Size {
+		u := -1
+		for v := range g.Size {
+			if !inTree[v] && (u == -1 || cost[v] < cost[u]) {
+				u = v
+			}
+		}
+
+		if math.IsInf(cost[u], 1) {
+			return nil, 0, errors.New("graph is not connected")
+		}
+
+		inTree[u] = true
+		total += cost[u]
+		if parent[u] != -1 {
+			edges = append(edges, [2]int{parent[u], u})
+		}
+
+		for v := range g.Size {
+			if g.HasEdge(u, v) && !inTree[v] && g.Weights[u][v] < cost[v] {
+				cost[v], parent[v] = g.Weights[u][v], u
+			}
+		}
+	}
+
+	return edges, total, nil
+}
+
+// returns vertices in topological order (Kahn's algorithm).
+// the smallest available vertex goes first
+func (g *Graph) TopologicalSort() ([]int, error) {
+	indegree := make([]int, g.Size)
+	for u := range g.Size {
+		for v := range g.Size {
+			if g.HasEdge(u, v) {
+				indegree[v]++
+			}
+		}
+	}
+
+	var order []int
+	done := make([]bool, g.Size)
+	for len(order) < g.Size {
+		u := -1
+		for v := range g.Size {
+			if !done[v] && indegree[v] == 0 {
+				u = v
+				break
+			}
+		}
+
+		if u == -1 {
+			return nil, errors.New("graph has a cycle")
+		}
+
+		done[u] = true
+		order = append(order, u)
+		for v := range g.Size {
+			if g.HasEdge(u, v) {
+				indegree[v]--
+			}
+		}
+	}
+
+	return order, nil
+}
+
+// longer walks are not counted, CountPaths returns an error
+const MaxPathLength = 1 << 20
+
+// returns matrix of the number of walks with exactly length edges
+// between every pair of vertices, as the power of adjacency matrix
+func (g *Graph) CountPaths(length int) ([][]float64, error) {
+	if length < 0 {
+		return nil, fmt.Errorf("length %d is negative", length)
+	}
+	if length > MaxPathLength {
+		return nil, fmt.Errorf("length should be at most %d, got %d", MaxPathLength, length)
+	}
+
+	adjacency, _ := matrix.NewBlankMatrix(g.Size, g.Size, false)
+	result, _ := matrix.NewBlankMatrix(g.Size, g.Size, false)
+	for u := range g.Size {
+		result.Data[u][u] = 1
+		for v := range g.Size {
+			if g.HasEdge(u, v) {
+				adjacency.Data[u][v] = 1
+			}
+		}
+	}
+
+	// binary exponentiation
+	for square := adjacency; length > 0; length >>= 1 {
+		if length&1 == 1 {
+			result, _ = matrix.NewMatrix(result.Multiply(square), false)
+		}
+		if length > 1 {
+			square, _ = matrix.NewMatrix(square.Multiply(square), false)
+		}
+	}
+
+	return result.Data, nil
+}
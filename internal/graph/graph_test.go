@@ -0,0 +1,204 @@
+package graph
+
+import (
+	"math"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/shimeoki/mlat/internal/matrix"
+)
+
+// 1 -> 2 -> 3 -> 1, 3 -> 4, 5 is isolated
+var directed *Graph
+
+// weighted undirected graph
+var weighted *Graph
+
+func TestMain(m *testing.M) {
+	directedMatrix, _ := matrix.NewMatrix([][]float64{
+		{0, 1, 0, 0, 0},
+		{0, 0, 1, 0, 0},
+		{1, 0, 0, 1, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+	}, false)
+	directed, _ = New(directedMatrix)
+
+	weightedMatrix, _ := matrix.NewMatrix([][]float64{
+		{0, 4, 1, 0},
+		{4, 0, 2, 5},
+		{1, 2, 0, 8},
+		{0, 5, 8, 0},
+	}, false)
+	weighted, _ = New(weightedMatrix)
+
+	tests := m.Run()
+
+	os.Exit(tests)
+}
+
+func TestSearch(t *testing.T) {
+	g := weighted
+
+	bfs, _ := g.BFS(0)
+	if want := []int{0, 1, 2, 3}; !reflect.DeepEqual(bfs, want) {
+		t.Errorf("bfs: got %v, want %v", bfs, want)
+	}
+
+	dfs, _ := g.DFS(0)
+	if want := []int{0, 1, 2, 3}; !reflect.DeepEqual(dfs, want) {
+		t.Errorf("dfs: got %v, want %v", dfs, want)
+	}
+
+	dfs, _ = g.DFS(3)
+	if want := []int{3, 1, 0, 2}; !reflect.DeepEqual(dfs, want) {
+		t.Errorf("dfs: got %v, want %v", dfs, want)
+	}
+
+	if _, err := g.BFS(4); err == nil {
+		t.Error("expected error for vertex out of range")
+	}
+}
+
+func TestComponents(t *testing.T) {
+	g := directed
+
+	components := g.Components()
+	if want := [][]int{{0, 1, 2, 3}, {4}}; !reflect.DeepEqual(components, want) {
+		t.Errorf("components: got %v, want %v", components, want)
+	}
+
+	strong := g.StronglyConnectedComponents()
+	if want := [][]int{{0, 1, 2}, {3}, {4}}; !reflect.DeepEqual(strong, want) {
+		t.Errorf("strong components: got %v, want %v", strong, want)
+	}
+}
+
+func TestFloydWarshall(t *testing.T) {
+	g := weighted
+
+	paths, err := g.FloydWarshall()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "0 3 1 8\n3 0 2 5\n1 2 0 7\n8 5 7 0\n"
+	if ans := matrix.MatrixToString(paths.Dist, " "); ans != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", ans, want)
+	}
+
+	if path := paths.Path(0, 3); !reflect.DeepEqual(path, []int{0, 2, 1, 3}) {
+		t.Errorf("path: got %v", path)
+	}
+
+	paths, _ = directed.FloydWarshall()
+	if !math.IsInf(paths.Dist[3][0], 1) || paths.Path(3, 0) != nil {
+		t.Error("vertex 0 should be unreachable from 3")
+	}
+
+	negative, _ := matrix.NewMatrix([][]float64{{0, 1}, {-2, 0}}, false)
+	g, _ = New(negative)
+	if _, err := g.FloydWarshall(); err == nil {
+		t.Error("expected error for negative cycle")
+	}
+}
+
+func TestDijkstra(t *testing.T) {
+	paths, err := weighted.Dijkstra(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []float64{0, 3, 1, 8}; !reflect.DeepEqual(paths.Dist, want) {
+		t.Errorf("dist: got %v, want %v", paths.Dist, want)
+	}
+
+	if path := paths.Path(3); !reflect.DeepEqual(path, []int{0, 2, 1, 3}) {
+		t.Errorf("path: got %v", path)
+	}
+
+	paths, _ = directed.Dijkstra(3)
+	if paths.Path(0) != nil {
+		t.Error("vertex 0 should be unreachable from 3")
+	}
+
+	negative, _ := matrix.NewMatrix([][]float64{{0, -1}, {0, 0}}, false)
+	g, _ := New(negative)
+	if _, err := g.Dijkstra(0); err == nil {
+		t.Error("expected error for negative weight")
+	}
+}
+
+func TestMinimumSpanningTree(t *testing.T) {
+	edges, total, err := weighted.MinimumSpanningTree()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := [][2]int{{0, 2}, {2, 1}, {1, 3}}; !reflect.DeepEqual(edges, want) || total != 8 {
+		t.Errorf("got %v with weight %v", edges, total)
+	}
+
+	if _, _, err := directed.MinimumSpanningTree(); err == nil {
+		t.Error("expected error for directed graph")
+	}
+
+	disconnected, _ := matrix.NewMatrix([][]float64{{0, 1, 0}, {1, 0, 0}, {0, 0, 0}}, false)
+	g, _ := New(disconnected)
+	if _, _, err := g.MinimumSpanningTree(); err == nil {
+		t.Error("expected error for disconnected graph")
+	}
+}
+
+func TestTopologicalSort(t *testing.T) {
+	dag, _ := matrix.NewMatrix([][]float64{
+		{0, 0, 1, 0},
+		{1, 0, 0, 1},
+		{0, 0, 0, 0},
+		{1, 0, 1, 0},
+	}, false)
+	g, _ := New(dag)
+
+	order, err := g.TopologicalSort()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{1, 3, 0, 2}; !reflect.DeepEqual(order, want) {
+		t.Errorf("got %v, want %v", order, want)
+	}
+
+	if _, err := directed.TopologicalSort(); err == nil {
+		t.Error("expected error for graph with cycle")
+	}
+}
+
+func TestCountPaths(t *testing.T) {
+	g := directed
+
+	tests := []struct {
+		length int
+		want   string
+	}{
+		{0, "1 0 0 0 0\n0 1 0 0 0\n0 0 1 0 0\n0 0 0 1 0\n0 0 0 0 1\n"},
+		{1, "0 1 0 0 0\n0 0 1 0 0\n1 0 0 1 0\n0 0 0 0 0\n0 0 0 0 0\n"},
+		{2, "0 0 1 0 0\n1 0 0 1 0\n0 1 0 0 0\n0 0 0 0 0\n0 0 0 0 0\n"},
+		{7, "0 1 0 0 0\n0 0 1 0 0\n1 0 0 1 0\n0 0 0 0 0\n0 0 0 0 0\n"},
+	}
+
+	for _, test := range tests {
+		counts, err := g.CountPaths(test.length)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ans := matrix.MatrixToString(counts, " "); ans != test.want {
+			t.Errorf("length %d:\ngot:\n%s\nwant:\n%s", test.length, ans, test.want)
+		}
+	}
+
+	for _, length := range []int{-1, 1e9} {
+		if _, err := g.CountPaths(length); err == nil {
+			t.Errorf("expected error for length %d", length)
+		}
+	}
+}
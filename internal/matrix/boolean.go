@@ -0,0 +1,282 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// 0/1 matrix with values packed into bits. every row takes whole words,
+// so logical operations on rows are done word by word
+type BoolMatrix struct {
+	Rows  int
+	Cols  int
+	words int
+	bits  []uint64
+}
+
+func NewBoolMatrix(rows, cols int) (*BoolMatrix, error) {
+	if rows <= 0 || cols <= 0 {
+		return nil, errors.New("invalid row or column value")
+	}
+
+	words := (cols + 63) / 64
+	return &BoolMatrix{
+		Rows:  rows,
+		Cols:  cols,
+		words: words,
+		bits:  make([]uint64, rows*words),
+	}, nil
+}
+
+// returns identity matrix of size n
+func NewBoolIdentity(n int) (*BoolMatrix, error) {
+	b, err := NewBoolMatrix(n, n)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range n {
+		b.Set(i, i, true)
+	}
+
+	return b, nil
+}
+
+// converts matrix with 0 and 1 values
+func NewBoolMatrixFromData(data [][]float64) (*BoolMatrix, error) {
+	if len(data) == 0 || len(data[0]) == 0 {
+		return nil, errors.New("error: data is empty")
+	}
+
+	b, err := NewBoolMatrix(len(data), len(data[0]))
+	if err != nil {
+		return nil, err
+	}
+
+	for i, row := range data {
+		if len(row) != b.Cols {
+			return nil, errors.New("error: data is not rectangle-shaped")
+		}
+
+		for j, value := range row {
+			switch value {
+			case 0:
+			case 1:
+				b.Set(i, j, true)
+			default:
+				return nil, fmt.Errorf("value %v at (%d, %d) is not 0 or 1", value, i+1, j+1)
+			}
+		}
+	}
+
+	return b, nil
+}
+
+func (b *BoolMatrix) row(i int) []uint64 {
+	return b.bits[i*b.words : (i+1)*b.words]
+}
+
+func (b *BoolMatrix) Get(i, j int) bool {
+	return b.bits[i*b.words+j/64]&(1<<(j%64)) != 0
+}
+
+func (b *BoolMatrix) Set(i, j int, value bool) {
+	if value {
+		b.bits[i*b.words+j/64] |= 1 << (j % 64)
+	} else {
+		b.bits[i*b.words+j/64] &^= 1 << (j % 64)
+	}
+}
+
+// returns number of ones in the matrix
+func (b *BoolMatrix) Count() int {
+	count := 0
+	for _, word := range b.bits {
+		count += bits.OnesCount64(word)
+	}
+	return count
+}
+
+func (b *BoolMatrix) Clone() *BoolMatrix {
+	clone := *b
+	clone.bits = append([]uint64(nil), b.bits...)
+	return &clone
+}
+
+func (b *BoolMatrix) Equal(other *BoolMatrix) bool {
+	if b.Rows != other.Rows || b.Cols != other.Cols {
+		return false
+	}
+
+	for i, word := range b.bits {
+		if word != other.bits[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// returns values as 0 and 1
+func (b *BoolMatrix) Data() [][]float64 {
+	data, memory, _ := Malloc[float64](b.Rows, b.Cols)
+	for i := range b.Rows {
+		data[i] = memory[i*b.Cols : (i+1)*b.Cols]
+		for j := range b.Cols {
+			if b.Get(i, j) {
+				data[i][j] = 1
+			}
+		}
+	}
+	return data
+}
+
+func (b *BoolMatrix) String() string {
+	var sb strings.Builder
+	for i := range b.Rows {
+		for j := range b.Cols {
+			if j > 0 {
+				sb.WriteByte(' ')
+			}
+			if b.Get(i, j) {
+				sb.WriteByte('1')
+			} else {
+				sb.WriteByte('0')
+			}
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+func (b *BoolMatrix) Transpose() *BoolMatrix {
+	transpose, _ := NewBoolMatrix(b.Cols, b.Rows)
+	for i := range b.Rows {
+		for j := range b.Cols {
+			if b.Get(i, j) {
+				transpose.Set(j, i, true)
+			}
+		}
+	}
+	return transpose
+}
+
+func (b *BoolMatrix) elementwise(other *BoolMatrix, operation func(x, y uint64) uint64) (*BoolMatrix, error) {
+	if b.Rows != other.Rows || b.Cols != other.Cols {
+		return nil, fmt.Errorf(
+			"dimensions %dx%d and %dx%d do not match",
+			b.Rows, b.Cols, other.Rows, other.Cols,
+		)
+	}
+
+	result := b.Clone()
+	for i := range result.bits {
+		result.bits[i] = operation(b.bits[i], other.bits[i])
+	}
+
+	return result, nil
+}
+
+// returns elementwise disjunction (union of relations)
+func (b *BoolMatrix) Or(other *BoolMatrix) (*BoolMatrix, error) {
+	return b.elementwise(other, func(x, y uint64) uint64 { return x | y })
+}
+
+// returns elementwise conjunction (intersection of relations)
+func (b *BoolMatrix) And(other *BoolMatrix) (*BoolMatrix, error) {
+	return b.elementwise(other, func(x, y uint64) uint64 { return x & y })
+}
+
+// returns boolean product: c[i][j] = OR over k of (b[i][k] AND other[k][j]).
+// for relations it is the composition
+func (b *BoolMatrix) Product(other *BoolMatrix) (*BoolMatrix, error) {
+	if b.Cols != other.Rows {
+		return nil, fmt.Errorf(
+			"dimensions %dx%d and %dx%d do not match",
+			b.Rows, b.Cols, other.Rows, other.Cols,
+		)
+	}
+
+	result, _ := NewBoolMatrix(b.Rows, other.Cols)
+	for i := range b.Rows {
+		row := result.row(i)
+		for k := range b.Cols {
+			if !b.Get(i, k) {
+				continue
+			}
+			for w, word := range other.row(k) {
+				row[w] |= word
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// returns k-th boolean power. zero power is the identity
+func (b *BoolMatrix) Power(k int) (*BoolMatrix, error) {
+	if b.Rows != b.Cols {
+		return nil, errors.New("matrix is not a square")
+	}
+
+	if k < 0 {
+		return nil, fmt.Errorf("power %d is negative", k)
+	}
+
+	result, _ := NewBoolIdentity(b.Rows)
+	base := b.Clone()
+	for ; k > 0; k >>= 1 {
+		if k&1 == 1 {
+			result, _ = result.Product(base)
+		}
+		base, _ = base.Product(base)
+	}
+
+	return result, nil
+}
+
+// returns transitive closure computed with Warshall's algorithm
+func (b *BoolMatrix) TransitiveClosure() (*BoolMatrix, error) {
+	if b.Rows != b.Cols {
+		return nil, errors.New("matrix is not a square")
+	}
+
+	closure := b.Clone()
+	for k := range closure.Rows {
+		rowK := closure.row(k)
+		for i := range closure.Rows {
+			if !closure.Get(i, k) {
+				continue
+			}
+			row := closure.row(i)
+			for w, word := range rowK {
+				row[w] |= word
+			}
+		}
+	}
+
+	return closure, nil
+}
+
+func (b *BoolMatrix) ReflexiveClosure() (*BoolMatrix, error) {
+	if b.Rows != b.Cols {
+		return nil, errors.New("matrix is not a square")
+	}
+
+	closure := b.Clone()
+	for i := range closure.Rows {
+		closure.Set(i, i, true)
+	}
+
+	return closure, nil
+}
+
+func (b *BoolMatrix) SymmetricClosure() (*BoolMatrix, error) {
+	if b.Rows != b.Cols {
+		return nil, errors.New("matrix is not a square")
+	}
+
+	return b.Or(b.Transpose())
+}
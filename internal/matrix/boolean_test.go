@@ -0,0 +1,161 @@
+package matrix
+
+import (
+	"testing"
+)
+
+func TestNewBoolMatrixFromData(t *testing.T) {
+	if _, err := NewBoolMatrixFromData([][]float64{{0, 2}}); err == nil {
+		t.Error("expected error for value 2")
+	}
+
+	// more than one word per row
+	data := make([][]float64, 2)
+	data[0] = make([]float64, 130)
+	data[1] = make([]float64, 130)
+	data[0][129] = 1
+	data[1][64] = 1
+
+	b, err := NewBoolMatrixFromData(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !b.Get(0, 129) || !b.Get(1, 64) || b.Get(0, 64) || b.Count() != 2 {
+		t.Errorf("wrong bits:\n%s", b)
+	}
+}
+
+func TestBoolProduct(t *testing.T) {
+	tests := []struct {
+		name    string
+		matrix1 [][]float64
+		matrix2 [][]float64
+		or      string
+		and     string
+		product string
+	}{
+		{"2x2",
+			[][]float64{{1, 0}, {1, 1}},
+			[][]float64{{0, 1}, {1, 0}},
+			"1 1\n1 1\n", "0 0\n1 0\n", "0 1\n1 1\n"},
+		{"3x3",
+			[][]float64{{0, 1, 0}, {0, 0, 1}, {0, 0, 0}},
+			[][]float64{{0, 1, 0}, {0, 0, 1}, {0, 0, 0}},
+			"0 1 0\n0 0 1\n0 0 0\n", "0 1 0\n0 0 1\n0 0 0\n", "0 0 1\n0 0 0\n0 0 0\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				a, _ := NewBoolMatrixFromData(test.matrix1)
+				b, _ := NewBoolMatrixFromData(test.matrix2)
+
+				or, _ := a.Or(b)
+				if or.String() != test.or {
+					t.Errorf("or:\ngot:\n%s\nwant:\n%s", or, test.or)
+				}
+
+				and, _ := a.And(b)
+				if and.String() != test.and {
+					t.Errorf("and:\ngot:\n%s\nwant:\n%s", and, test.and)
+				}
+
+				product, _ := a.Product(b)
+				if product.String() != test.product {
+					t.Errorf("product:\ngot:\n%s\nwant:\n%s", product, test.product)
+				}
+			})
+	}
+
+	a, _ := NewBoolMatrixFromData([][]float64{{1, 0}})
+	b, _ := NewBoolMatrixFromData([][]float64{{1}})
+	if _, err := a.Product(a); err == nil {
+		t.Error("expected dimension error")
+	}
+	if _, err := a.Or(b); err == nil {
+		t.Error("expected dimension error")
+	}
+}
+
+func TestBoolPower(t *testing.T) {
+	// cycle 1 -> 2 -> 3 -> 1
+	cycle, _ := NewBoolMatrixFromData([][]float64{{0, 1, 0}, {0, 0, 1}, {1, 0, 0}})
+
+	tests := []struct {
+		arg  int
+		want string
+	}{
+		{0, "1 0 0\n0 1 0\n0 0 1\n"},
+		{1, "0 1 0\n0 0 1\n1 0 0\n"},
+		{2, "0 0 1\n1 0 0\n0 1 0\n"},
+		{3, "1 0 0\n0 1 0\n0 0 1\n"},
+		{5, "0 0 1\n1 0 0\n0 1 0\n"},
+	}
+
+	for _, test := range tests {
+		power, err := cycle.Power(test.arg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if power.String() != test.want {
+			t.Errorf("power %d:\ngot:\n%s\nwant:\n%s", test.arg, power, test.want)
+		}
+	}
+
+	if _, err := cycle.Power(-1); err == nil {
+		t.Error("expected error for negative power")
+	}
+}
+
+func TestBoolClosures(t *testing.T) {
+	tests := []struct {
+		name       string
+		matrix     [][]float64
+		transitive string
+		reflexive  string
+		symmetric  string
+	}{
+		{"chain",
+			[][]float64{{0, 1, 0, 0}, {0, 0, 1, 0}, {0, 0, 0, 1}, {0, 0, 0, 0}},
+			"0 1 1 1\n0 0 1 1\n0 0 0 1\n0 0 0 0\n",
+			"1 1 0 0\n0 1 1 0\n0 0 1 1\n0 0 0 1\n",
+			"0 1 0 0\n1 0 1 0\n0 1 0 1\n0 0 1 0\n"},
+		{"cycle",
+			[][]float64{{0, 1, 0}, {0, 0, 1}, {1, 0, 0}},
+			"1 1 1\n1 1 1\n1 1 1\n",
+			"1 1 0\n0 1 1\n1 0 1\n",
+			"0 1 1\n1 0 1\n1 1 0\n"},
+		{"empty",
+			[][]float64{{0, 0}, {0, 0}},
+			"0 0\n0 0\n",
+			"1 0\n0 1\n",
+			"0 0\n0 0\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				b, _ := NewBoolMatrixFromData(test.matrix)
+
+				transitive, _ := b.TransitiveClosure()
+				if transitive.String() != test.transitive {
+					t.Errorf("transitive:\ngot:\n%s\nwant:\n%s", transitive, test.transitive)
+				}
+
+				reflexive, _ := b.ReflexiveClosure()
+				if reflexive.String() != test.reflexive {
+					t.Errorf("reflexive:\ngot:\n%s\nwant:\n%s", reflexive, test.reflexive)
+				}
+
+				symmetric, _ := b.SymmetricClosure()
+				if symmetric.String() != test.symmetric {
+					t.Errorf("symmetric:\ngot:\n%s\nwant:\n%s", symmetric, test.symmetric)
+				}
+
+				// closure of the original matrix should not be changed
+				if b.String() != MatrixToString(test.matrix, " ") {
+					t.Error("original matrix was changed")
+				}
+			})
+	}
+}
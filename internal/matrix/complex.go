@@ -0,0 +1,196 @@
+package matrix
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// matrix with complex128 values. mirrors Matrix for determinants, roots, inverse and product
+type ComplexMatrix struct {
+	Data         [][]complex128
+	Rows         int
+	Cols         int
+	Augmented    bool
+	Square       bool
+	Determinants []complex128
+	Roots        []complex128
+
+	// metadata from the header of the text file
+	Name      string
+	Precision int // digits after the point when written, 0 is the shortest form
+}
+
+func NewComplexMatrix(data [][]complex128, augmented bool) (*ComplexMatrix, error) {
+	if data == nil || data[0] == nil {
+		return nil, errors.New("error: data is nil")
+	}
+
+	rows, cols := len(data), len(data[0])
+	if rows == 0 || cols == 0 {
+		return nil, errors.New("error: data is empty")
+	}
+
+	matrix, memory, _ := Malloc[complex128](rows, cols)
+	for i := range rows {
+		if len(data[i]) != cols {
+			return nil, errors.New("error: data is not rectangle-shaped")
+		}
+
+		matrix[i] = memory[(i * cols):((i + 1) * cols)]
+		copy(matrix[i], data[i])
+	}
+
+	m := &ComplexMatrix{Data: matrix, Rows: rows, Cols: cols, Augmented: augmented}
+	m.Square = isSquare(m.Augmented, m.Rows, m.Cols)
+	m.Determinants = makeDets[complex128](m.Augmented, m.Cols)
+	m.Roots = makeRoots[complex128](m.Augmented, m.Cols)
+
+	return m, nil
+}
+
+// converts real matrix to complex with zero imaginary parts
+func NewComplexMatrixFromReal(m *Matrix) (*ComplexMatrix, error) {
+	return NewComplexMatrix(toComplex(m.Data), m.Augmented)
+}
+
+func toComplex(matrix [][]float64) [][]complex128 {
+	rows, cols := len(matrix), len(matrix[0])
+
+	newMatrix, memory, _ := Malloc[complex128](rows, cols)
+	for i := range rows {
+		newMatrix[i] = memory[i*cols : (i+1)*cols]
+		for j := range cols {
+			newMatrix[i][j] = complex(matrix[i][j], 0)
+		}
+	}
+
+	return newMatrix
+}
+
+// reports whether all imaginary parts are zero
+func (m *ComplexMatrix) IsReal() bool {
+	for _, row := range m.Data {
+		for _, value := range row {
+			if imag(value) != 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// returns real parts of the values
+func (m *ComplexMatrix) Real() [][]float64 {
+	newMatrix, memory, _ := Malloc[float64](m.Rows, m.Cols)
+	for i := range m.Rows {
+		newMatrix[i] = memory[i*m.Cols : (i+1)*m.Cols]
+		for j := range m.Cols {
+			newMatrix[i][j] = real(m.Data[i][j])
+		}
+	}
+
+	return newMatrix
+}
+
+// same as Matrix.Calculate
+func (m *ComplexMatrix) Calculate() ([]complex128, error) {
+	if !m.Square {
+		return nil, errors.New("matrix is not a square")
+	}
+
+	if !m.Augmented {
+		m.Determinants[0] = calcDet(m.Data)
+	} else {
+		m.Determinants = calcDets(m.Data)
+		m.GetRoots()
+	}
+
+	return m.Determinants, nil
+}
+
+func (m *ComplexMatrix) GetRoots() []complex128 {
+	if m.Determinants == nil {
+		return nil
+	}
+
+	if m.Determinants[0] == 0 || !m.Augmented {
+		return nil
+	}
+
+	for i := range m.Cols - 1 {
+		m.Roots[i] = m.Determinants[i+1] / m.Determinants[0]
+	}
+
+	return m.Roots
+}
+
+func (m *ComplexMatrix) GetTranspose() [][]complex128 {
+	return transpose(m.Data)
+}
+
+// returns conjugate transpose
+func (m *ComplexMatrix) GetAdjoint() [][]complex128 {
+	adjoint := transpose(m.Data)
+	for i := range adjoint {
+		for j := range adjoint[i] {
+			adjoint[i][j] = complex(real(adjoint[i][j]), -imag(adjoint[i][j]))
+		}
+	}
+
+	return adjoint
+}
+
+func (m *ComplexMatrix) GetInverse() [][]complex128 {
+	if !m.Square || m.Augmented {
+		return nil
+	}
+
+	det := calcDet(m.Data)
+	if det == 0 {
+		return nil
+	}
+
+	return inverse(m.Data, det)
+}
+
+func (m *ComplexMatrix) Multiply(matrix *ComplexMatrix) [][]complex128 {
+	if m.Cols != matrix.Rows {
+		return nil
+	}
+
+	return multiply(m.Data, matrix.Data)
+}
+
+// parses complex number in the forms "3", "4i", "3+4i", "3-i" or "(3+4i)"
+func ParseComplex(s string) (complex128, error) {
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "("), ")")
+
+	// fractions are real only
+	if strings.Contains(s, "/") {
+		value, err := parseFloat(s)
+		return complex(value, 0), err
+	}
+
+	// strconv requires a coefficient before the imaginary unit
+	if strings.HasSuffix(s, "i") {
+		body := s[:len(s)-1]
+		if body == "" || strings.HasSuffix(body, "+") || strings.HasSuffix(body, "-") {
+			s = body + "1i"
+		}
+	}
+
+	return strconv.ParseComplex(s, 128)
+}
+
+func formatComplex(c complex128) string {
+	switch {
+	case imag(c) == 0:
+		return strconv.FormatFloat(real(c), 'g', -1, 64)
+	case real(c) == 0:
+		return strconv.FormatFloat(imag(c), 'g', -1, 64) + "i"
+	default:
+		formatted := strconv.FormatComplex(c, 'g', -1, 128)
+		return formatted[1 : len(formatted)-1]
+	}
+}
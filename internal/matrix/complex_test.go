@@ -0,0 +1,134 @@
+package matrix
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestParseComplex(t *testing.T) {
+	tests := []struct {
+		arg     string
+		want    complex128
+		wantErr bool
+	}{
+		{"3", 3, false},
+		{"-2.5", -2.5, false},
+		{"4i", 4i, false},
+		{"i", 1i, false},
+		{"-i", -1i, false},
+		{"3+4i", 3 + 4i, false},
+		{"3-i", 3 - 1i, false},
+		{"(1+2i)", 1 + 2i, false},
+		{"1e2-1e1i", 100 - 10i, false},
+		{"3+4j", 0, true},
+		{"abc", 0, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.arg,
+			func(t *testing.T) {
+				ans, err := ParseComplex(test.arg)
+				if (err != nil) != test.wantErr {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if !test.wantErr && ans != test.want {
+					t.Errorf("got %v, want %v", ans, test.want)
+				}
+			})
+	}
+}
+
+func TestComplexToString(t *testing.T) {
+	ans := ArrayToString([]complex128{3 + 4i, 2, -1i, 0, 1.5 - 0.5i}, " ")
+	want := "3+4i 2 -1i 0 1.5-0.5i"
+	if ans != want {
+		t.Errorf("got %q, want %q", ans, want)
+	}
+}
+
+func TestComplexCalculate(t *testing.T) {
+	tests := []struct {
+		name      string
+		matrix    [][]complex128
+		augmented bool
+		dets      string
+		roots     string
+	}{
+		{"1x1", [][]complex128{{3 + 4i}}, false, "3+4i", ""},
+		{"2x2", [][]complex128{{1i, 2}, {3, 4i}}, false, "-10", ""},
+		{"3x3 real", [][]complex128{{1, 2, 3}, {4, 5, 6}, {7, 8, 10}}, false, "-3", ""},
+		// ix + y = 1+i, x + y = 2  =>  x = 1, y = 1
+		{"system", [][]complex128{{1i, 1, 1 + 1i}, {1, 1, 2}}, true, "-1+1i -1+1i -1+1i", "1 1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				matrix, err := NewComplexMatrix(test.matrix, test.augmented)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				dets, err := matrix.Calculate()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if ans := ArrayToString(dets, " "); ans != test.dets {
+					t.Errorf("determinants: got %q, want %q", ans, test.dets)
+				}
+				if ans := ArrayToString(matrix.GetRoots(), " "); ans != test.roots {
+					t.Errorf("roots: got %q, want %q", ans, test.roots)
+				}
+			})
+	}
+}
+
+func TestComplexInverse(t *testing.T) {
+	matrix, _ := NewComplexMatrix([][]complex128{{2, 1i}, {1i, 0}}, false)
+
+	inverse := matrix.GetInverse()
+	if inverse == nil {
+		t.Fatal("matrix is singular")
+	}
+
+	other, _ := NewComplexMatrix(inverse, false)
+	ans := MatrixToString(matrix.Multiply(other), " ")
+	if want := "1 0\n0 1\n"; ans != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", ans, want)
+	}
+
+	singular, _ := NewComplexMatrix([][]complex128{{1, 1i}, {1i, -1}}, false)
+	if singular.GetInverse() != nil {
+		t.Error("expected nil inverse of singular matrix")
+	}
+}
+
+func TestComplexReadWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "matrix.txt")
+	data := [][]complex128{{3 + 4i, -1i}, {2, 0.5 - 1.5i}}
+
+	if err := Write(path, data); err != nil {
+		t.Fatal(err)
+	}
+
+	ans, err := ReadComplexSlow(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if MatrixToString(ans, " ") != MatrixToString(data, " ") {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", MatrixToString(ans, " "), MatrixToString(data, " "))
+	}
+}
+
+func TestComplexIsReal(t *testing.T) {
+	realMatrix, _ := NewComplexMatrixFromReal(&Matrix{Data: matrix6})
+	if !realMatrix.IsReal() {
+		t.Error("expected real matrix")
+	}
+
+	complexMatrix, _ := NewComplexMatrix([][]complex128{{1, 2}, {3, 1e-300i}}, false)
+	if complexMatrix.IsReal() {
+		t.Error("expected matrix with imaginary parts")
+	}
+}
@@ -0,0 +1,181 @@
+package matrix
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// delimiters detected in csv files, in the order of preference
+var csvDelimiters = []rune{'\t', ';', ','}
+
+// delimiter is detected by this number of the first records
+const delimiterSampleRecords = 8
+
+// returns delimiter for ".csv" and ".tsv" extensions. delimiter of csv
+// is 0, so it is detected on reading. ok is false for other extensions
+func CSVDelimiter(extension string) (delimiter rune, ok bool) {
+	switch strings.ToLower(extension) {
+	case ".csv":
+		return 0, true
+	case ".tsv":
+		return '\t', true
+	default:
+		return 0, false
+	}
+}
+
+// returns the first delimiter from csvDelimiters that splits the first
+// records of the data into the same number of fields, more than one.
+// semicolon goes before comma, because it is used with decimal commas
+func detectDelimiter(data []byte) rune {
+	for _, delimiter := range csvDelimiters {
+		reader := newCSVReader(bytes.NewReader(data), delimiter)
+
+		fields := 0
+		for range delimiterSampleRecords {
+			record, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				fields = 0
+				break
+			}
+			fields = len(record)
+		}
+
+		if fields > 1 {
+			return delimiter
+		}
+	}
+	return ','
+}
+
+func newCSVReader(r io.Reader, delimiter rune) *csv.Reader {
+	reader := csv.NewReader(r)
+	reader.Comma = delimiter
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+	return reader
+}
+
+// converts column in bytes to the column in characters
+func runeColumn(data []byte, line, column int) int {
+	for range line - 1 {
+		_, data, _ = bytes.Cut(data, []byte("\n"))
+	}
+	return utf8.RuneCount(data[:min(column-1, len(data))]) + 1
+}
+
+// reads matrix from csv with the delimiter, it is detected by the first
+// lines if 0. cells can be quoted, lines after "#" are skipped. the first
+// row is a header if it has no numbers, it is returned separately
+func ReadCSV(r io.Reader, delimiter rune) ([][]float64, []string, error) {
+	return readCSV(r, delimiter, parseFloat)
+}
+
+// same as ReadCSV, but values can be complex
+func ReadComplexCSV(r io.Reader, delimiter rune) ([][]complex128, []string, error) {
+	return readCSV(r, delimiter, ParseComplex)
+}
+
+func readCSV[number Number](r io.Reader, delimiter rune, parse func(string) (number, error)) ([][]number, []string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if delimiter == 0 {
+		delimiter = detectDelimiter(data)
+	}
+
+	csvReader := newCSVReader(bytes.NewReader(data), delimiter)
+
+	// decimal separator is a comma if it is not the delimiter
+	cell := func(field string) string {
+		field = strings.TrimSpace(field)
+		if delimiter != ',' {
+			field = strings.ReplaceAll(field, ",", ".")
+		}
+		return field
+	}
+
+	var memory []number
+	var header []string
+	rows, cols := 0, 0
+	for {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if rows == 0 && header == nil && isHeader(record, cell, parse) {
+			header = record
+			continue
+		}
+
+		cols = len(record)
+		for j, field := range record {
+			value, err := parse(cell(field))
+			if err != nil {
+				line, column := csvReader.FieldPos(j)
+				return nil, nil, &ParseError{line, runeColumn(data, line, column), fmt.Errorf("%q is not a number", field)}
+			}
+			memory = append(memory, value)
+		}
+		rows++
+	}
+
+	if rows == 0 {
+		return nil, nil, errors.New("error: data is empty")
+	}
+
+	matrix := make([][]number, rows)
+	for i := range matrix {
+		matrix[i] = memory[(i * cols) : (i+1)*cols]
+	}
+
+	return matrix, header, nil
+}
+
+// reports whether every cell of the record is not a number
+func isHeader[number Number](record []string, cell func(string) string, parse func(string) (number, error)) bool {
+	for _, field := range record {
+		if _, err := parse(cell(field)); err == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// writes matrix as csv with the delimiter, comma if it is 0.
+// header is written as the first row if it is not nil
+func WriteCSV[number Number](w io.Writer, matrix [][]number, header []string, delimiter rune) error {
+	writer := csv.NewWriter(w)
+	if delimiter != 0 {
+		writer.Comma = delimiter
+	}
+
+	if header != nil {
+		writer.Write(header)
+	}
+
+	record := make([]string, len(matrix[0]))
+	for _, row := range matrix {
+		for j, value := range row {
+			record[j] = FormatNumber(value)
+		}
+		writer.Write(record)
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,147 @@
+package matrix
+
+import (
+	"encoding/csv"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadCSV(t *testing.T) {
+	// first line is longer than the buffer of the reader
+	long := [][]float64{make([]float64, 1200), make([]float64, 1200)}
+	for j := range 1199 {
+		long[0][j], long[1][j] = 1.5, 3
+	}
+	long[0][1199], long[1][1199] = 2, 4
+
+	tests := []struct {
+		name      string
+		input     string
+		delimiter rune
+		want      [][]float64
+		header    []string
+	}{
+		{"comma", "1,2,3\n4,5,6\n", 0, [][]float64{{1, 2, 3}, {4, 5, 6}}, nil},
+		{"spaces", "1, 2\n 3 ,4\n", 0, [][]float64{{1, 2}, {3, 4}}, nil},
+		{"semicolon", "1,5;2\n-3;4,25\n", 0, [][]float64{{1.5, 2}, {-3, 4.25}}, nil},
+		{"tab", "1\t2\n3\t4\n", 0, [][]float64{{1, 2}, {3, 4}}, nil},
+		{"explicit", "1,5\n", ';', [][]float64{{1.5}}, nil},
+		{"quoted", "\"1\",\"-2/5\"\n\"3e2\",4\n", 0, [][]float64{{1, -0.4}, {300, 4}}, nil},
+		{"header", "x,\"y, m\"\n1,2\n", 0, [][]float64{{1, 2}}, []string{"x", "y, m"}},
+		{"quoted delimiter", "\"x;y\",z\n1,2\n", 0, [][]float64{{1, 2}}, []string{"x;y", "z"}},
+		{"comments", "# data\n1,2\n\n3,4\n", 0, [][]float64{{1, 2}, {3, 4}}, nil},
+		{"column", "1\n2\n3\n", 0, matrix8, nil},
+		{"long lines", strings.Repeat("1,5;", 1199) + "2\n" + strings.Repeat("3;", 1199) + "4\n", 0,
+			long, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				ans, header, err := ReadCSV(strings.NewReader(test.input), test.delimiter)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				got := MatrixToString(ans, " ")
+				want := MatrixToString(test.want, " ")
+				if got != want {
+					t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+				}
+				if strings.Join(header, "|") != strings.Join(test.header, "|") {
+					t.Errorf("got header %q, want %q", header, test.header)
+				}
+			})
+	}
+}
+
+func TestReadCSVErrors(t *testing.T) {
+	_, _, err := ReadCSV(strings.NewReader("1,2\n3,x\n"), 0)
+	if err == nil || err.Error() != "line 2, column 3: \"x\" is not a number" {
+		t.Errorf("got %v", err)
+	}
+
+	// columns are counted in characters
+	_, _, err = ReadCSV(strings.NewReader("1,2\n\u00a03,x\n"), 0)
+	if err == nil || err.Error() != "line 2, column 4: \"x\" is not a number" {
+		t.Errorf("got %v", err)
+	}
+
+	// header is only the row without numbers
+	_, _, err = ReadCSV(strings.NewReader("1,x\n3,4\n"), 0)
+	if err == nil || err.Error() != "line 1, column 3: \"x\" is not a number" {
+		t.Errorf("got %v", err)
+	}
+
+	_, _, err = ReadCSV(strings.NewReader("1,2\n3\n"), 0)
+	if !errors.Is(err, csv.ErrFieldCount) {
+		t.Errorf("got %v, want %v", err, csv.ErrFieldCount)
+	}
+
+	for _, input := range []string{"", "# comment\n", "x,y\n"} {
+		if _, _, err := ReadCSV(strings.NewReader(input), 0); err == nil {
+			t.Errorf("expected error for input %q", input)
+		}
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	data := [][]float64{{1, -2.5}, {3, 4}}
+
+	var sb strings.Builder
+	if err := WriteCSV(&sb, data, []string{"a", "b c"}, '\t'); err != nil {
+		t.Fatal(err)
+	}
+	if want := "a\tb c\n1\t-2.5\n3\t4\n"; sb.String() != want {
+		t.Errorf("got %q, want %q", sb.String(), want)
+	}
+
+	ans, header, err := ReadCSV(strings.NewReader(sb.String()), 0)
+	if err != nil || len(header) != 2 || MatrixToString(ans, " ") != MatrixToString(data, " ") {
+		t.Errorf("matrix is not read back: %v, %q, %v", ans, header, err)
+	}
+
+	sb.Reset()
+	WriteCSV(&sb, [][]complex128{{1 + 2i, 3}}, nil, 0)
+	if want := "1+2i,3\n"; sb.String() != want {
+		t.Errorf("got %q, want %q", sb.String(), want)
+	}
+}
+
+func TestReadWriteByExtension(t *testing.T) {
+	data := [][]float64{{1, 2}, {3, 4}}
+
+	for _, name := range []string{"matrix.csv", "matrix.TSV", "matrix.txt", "matrix.mtx", "matrix.npy", "matrix.npz", "matrix.json", "matrix.yml"} {
+		path := filepath.Join(t.TempDir(), name)
+		if err := Write(path, data); err != nil {
+			t.Fatal(err)
+		}
+
+		content, _ := os.ReadFile(path)
+		want := map[string]string{
+			"matrix.csv": "1,2\n3,4\n",
+			"matrix.TSV": "1\t2\n3\t4\n",
+			"matrix.txt": "1 2\n3 4\n",
+			"matrix.mtx": "%%MatrixMarket matrix array real general\n2 2\n1\n3\n2\n4\n",
+			"matrix.yml": "data: [[1, 2], [3, 4]]\naugmented: false\n",
+		}[name]
+		// binary and json content is checked in TestWriteNPY, TestNPZ and TestMatrixJSON
+		if want != "" && string(content) != want {
+			t.Errorf("%s: got %q, want %q", name, content, want)
+		}
+
+		ans, err := ReadSlow(path)
+		if err != nil || MatrixToString(ans, " ") != MatrixToString(data, " ") {
+			t.Errorf("%s: got %v, %v", name, ans, err)
+		}
+	}
+}
+
+func TestMatrixToStringSeparator(t *testing.T) {
+	if got := MatrixToString(matrix6, ", "); got != "1, 2, 3\n4, 5, 6\n7, 8, 9\n" {
+		t.Errorf("got %q", got)
+	}
+}
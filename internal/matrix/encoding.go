@@ -0,0 +1,125 @@
+package matrix
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// representation of Matrix in json and yaml
+type matrixDocument struct {
+	Name         string      `json:"name,omitempty" yaml:"name,omitempty"`
+	Data         [][]float64 `json:"data" yaml:"data,flow"`
+	Augmented    bool        `json:"augmented" yaml:"augmented"`
+	Precision    int         `json:"precision,omitempty" yaml:"precision,omitempty"`
+	Determinants []float64   `json:"determinants,omitempty" yaml:"determinants,omitempty,flow"`
+	Roots        []float64   `json:"roots,omitempty" yaml:"roots,omitempty,flow"`
+}
+
+// determinants are written only if they were calculated for the current
+// data, roots only if the system has the unique solution
+func (m *Matrix) document() matrixDocument {
+	doc := matrixDocument{
+		Name:      m.Name,
+		Data:      m.Data,
+		Augmented: m.Augmented,
+		Precision: m.Precision,
+	}
+
+	if m.isCalculated() {
+		doc.Determinants = m.Determinants
+		if m.Augmented && m.Determinants[0] != 0 {
+			doc.Roots = m.Roots
+		}
+	}
+
+	return doc
+}
+
+// replaces the matrix with the decoded one. determinants and roots
+// are optional, but should have the same length as computed ones
+func (m *Matrix) setDocument(doc matrixDocument) error {
+	matrix, err := NewMatrix(doc.Data, doc.Augmented)
+	if err != nil {
+		return err
+	}
+
+	if doc.Determinants != nil {
+		if len(doc.Determinants) != len(matrix.Determinants) {
+			return fmt.Errorf("determinants should have %d values, got %d", len(matrix.Determinants), len(doc.Determinants))
+		}
+		copy(matrix.Determinants, doc.Determinants)
+		matrix.calculated = cloneData(matrix.Data)
+	}
+
+	if doc.Roots != nil {
+		if len(doc.Roots) != len(matrix.Roots) {
+			return fmt.Errorf("roots should have %d values, got %d", len(matrix.Roots), len(doc.Roots))
+		}
+		copy(matrix.Roots, doc.Roots)
+	}
+
+	matrix.Name, matrix.Precision = doc.Name, doc.Precision
+	*m = *matrix
+	return nil
+}
+
+func (m *Matrix) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.document())
+}
+
+func (m *Matrix) UnmarshalJSON(data []byte) error {
+	var doc matrixDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	return m.setDocument(doc)
+}
+
+func (m *Matrix) MarshalYAML() (any, error) {
+	return m.document(), nil
+}
+
+func (m *Matrix) UnmarshalYAML(value *yaml.Node) error {
+	var doc matrixDocument
+	if err := value.Decode(&doc); err != nil {
+		return err
+	}
+	return m.setDocument(doc)
+}
+
+// reads matrix encoded with json
+func ReadJSON(r io.Reader) (*Matrix, error) {
+	m := &Matrix{}
+	if err := json.NewDecoder(r).Decode(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// writes matrix with json, indented with 2 spaces
+func WriteJSON(w io.Writer, m *Matrix) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(m)
+}
+
+// reads matrix encoded with yaml
+func ReadYAML(r io.Reader) (*Matrix, error) {
+	m := &Matrix{}
+	if err := yaml.NewDecoder(r).Decode(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// writes matrix with yaml
+func WriteYAML(w io.Writer, m *Matrix) error {
+	encoder := yaml.NewEncoder(w)
+	if err := encoder.Encode(m); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
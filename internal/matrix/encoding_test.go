@@ -0,0 +1,133 @@
+package matrix
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestMatrixJSON(t *testing.T) {
+	matrix, _ := NewMatrix([][]float64{{2, 1, 5}, {1, -1, 1}}, true)
+	matrix.Name = "system"
+	matrix.Calculate()
+
+	data, err := json.Marshal(matrix)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"name":"system","data":[[2,1,5],[1,-1,1]],"augmented":true,"determinants":[-3,-6,-3],"roots":[2,1]}`
+	if string(data) != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", data, want)
+	}
+
+	var ans Matrix
+	if err := json.Unmarshal(data, &ans); err != nil {
+		t.Fatal(err)
+	}
+	if ans.Name != "system" || !ans.Augmented || ans.Rows != 2 || ans.Cols != 3 ||
+		ArrayToString(ans.Roots, " ") != "2 1" || ArrayToString(ans.Determinants, " ") != "-3 -6 -3" {
+		t.Errorf("matrix is not decoded: %+v", ans)
+	}
+
+	// not calculated matrix and singular system
+	matrix, _ = NewMatrix([][]float64{{1, 1, 1}, {2, 2, 3}}, true)
+	if data, _ := json.Marshal(matrix); string(data) != `{"data":[[1,1,1],[2,2,3]],"augmented":true}` {
+		t.Errorf("got %s", data)
+	}
+	matrix.Calculate()
+	if data, _ := json.Marshal(matrix); string(data) != `{"data":[[1,1,1],[2,2,3]],"augmented":true,"determinants":[0,-1,1]}` {
+		t.Errorf("got %s", data)
+	}
+
+	// data is edited after Calculate
+	matrix.SwapRows(0, 1)
+	if data, _ := json.Marshal(matrix); string(data) != `{"data":[[2,2,3],[1,1,1]],"augmented":true}` {
+		t.Errorf("got %s", data)
+	}
+	matrix.Data[0][0] = 5
+	matrix.Calculate()
+	matrix.Data[0][0] = 6
+	if data, _ := json.Marshal(matrix); strings.Contains(string(data), "determinants") {
+		t.Errorf("got %s", data)
+	}
+}
+
+func TestMatrixJSONErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"no data", `{"augmented":false}`},
+		{"not rectangle", `{"data":[[1,2],[3]]}`},
+		{"determinants", `{"data":[[1]],"determinants":[1,2]}`},
+		{"roots", `{"data":[[1,2]],"augmented":true,"roots":[1,2]}`},
+		{"syntax", `{"data":`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				var matrix Matrix
+				if err := json.Unmarshal([]byte(test.input), &matrix); err == nil {
+					t.Errorf("expected error, got %+v", matrix)
+				}
+			})
+	}
+}
+
+func TestMatrixYAML(t *testing.T) {
+	matrix, _ := NewMatrix([][]float64{{1, 2}, {3, 4}}, false)
+	matrix.Precision = 3
+
+	data, err := yaml.Marshal(matrix)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "data: [[1, 2], [3, 4]]\naugmented: false\nprecision: 3\n"
+	if string(data) != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", data, want)
+	}
+
+	var ans Matrix
+	input := "name: A\ndata:\n  - [1, 2]\n  - [3, 4]\ndeterminants: [-2]\n"
+	if err := yaml.Unmarshal([]byte(input), &ans); err != nil {
+		t.Fatal(err)
+	}
+	if ans.Name != "A" || ans.Augmented || MatrixToString(ans.Data, " ") != "1 2\n3 4\n" || ans.Determinants[0] != -2 {
+		t.Errorf("matrix is not decoded: %+v", ans)
+	}
+
+	if err := yaml.Unmarshal([]byte("data: [[1, 2], [3]]\n"), &ans); err == nil {
+		t.Error("expected error for not rectangle data")
+	}
+}
+
+func TestReadWriteEncoded(t *testing.T) {
+	matrix, _ := NewMatrix([][]float64{{1, 2, 3}}, true)
+
+	var sb strings.Builder
+	if err := WriteJSON(&sb, matrix); err != nil {
+		t.Fatal(err)
+	}
+	ans, err := ReadJSON(strings.NewReader(sb.String()))
+	if err != nil || !ans.Augmented || MatrixToString(ans.Data, " ") != "1 2 3\n" {
+		t.Errorf("json is not read back: %v, %v", ans, err)
+	}
+
+	sb.Reset()
+	if err := WriteYAML(&sb, matrix); err != nil {
+		t.Fatal(err)
+	}
+	ans, err = ReadYAML(strings.NewReader(sb.String()))
+	if err != nil || !ans.Augmented || MatrixToString(ans.Data, " ") != "1 2 3\n" {
+		t.Errorf("yaml is not read back: %v, %v", ans, err)
+	}
+
+	if _, err := ReadJSON(strings.NewReader("")); err == nil {
+		t.Error("expected error for empty json")
+	}
+}
@@ -0,0 +1,127 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// pivot smaller than this part of the largest value in the matrix
+// is treated as zero, so the matrix is singular
+const singularTolerance = 1e-12
+
+var errSingular = errors.New("matrix is singular")
+
+// solves a x = b with gaussian elimination and partial pivoting.
+// a should be square, b can have any number of columns
+func solve(a, b [][]float64) ([][]float64, error) {
+	n, cols := len(a), len(b[0])
+
+	scale := 0.0
+	for _, row := range a {
+		for _, value := range row {
+			scale = max(scale, math.Abs(value))
+		}
+	}
+
+	matrix, memory, _ := Malloc[float64](n, n+cols)
+	for i := range n {
+		matrix[i] = memory[i*(n+cols) : (i+1)*(n+cols)]
+		copy(matrix[i], a[i])
+		copy(matrix[i][n:], b[i])
+	}
+
+	for k := range n {
+		pivot := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(matrix[i][k]) > math.Abs(matrix[pivot][k]) {
+				pivot = i
+			}
+		}
+
+		if math.Abs(matrix[pivot][k]) <= singularTolerance*scale {
+			return nil, errSingular
+		}
+
+		matrix[k], matrix[pivot] = matrix[pivot], matrix[k]
+		for i := k + 1; i < n; i++ {
+			factor := matrix[i][k] / matrix[k][k]
+			for j := k; j < n+cols; j++ {
+				matrix[i][j] -= factor * matrix[k][j]
+			}
+		}
+	}
+
+	x, xMemory, _ := Malloc[float64](n, cols)
+	for i := n - 1; i >= 0; i-- {
+		x[i] = xMemory[i*cols : (i+1)*cols]
+		for j := range cols {
+			value := matrix[i][n+j]
+			for k := i + 1; k < n; k++ {
+				value -= matrix[i][k] * x[k][j]
+			}
+			x[i][j] = value / matrix[i][i]
+		}
+	}
+
+	return x, nil
+}
+
+func (m *Matrix) checkSquare() error {
+	if m.Rows != m.Cols {
+		return fmt.Errorf("matrix %dx%d is not a square", m.Rows, m.Cols)
+	}
+	return nil
+}
+
+// solves A X = B, where A is the matrix
+func (m *Matrix) SolveLeft(b *Matrix) (*Matrix, error) {
+	if err := m.checkSquare(); err != nil {
+		return nil, err
+	}
+
+	if m.Rows != b.Rows {
+		return nil, dimensionError(m, b)
+	}
+
+	x, err := solve(m.Data, b.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMatrix(x, false)
+}
+
+// solves X A = B, where A is the matrix
+func (m *Matrix) SolveRight(b *Matrix) (*Matrix, error) {
+	if err := m.checkSquare(); err != nil {
+		return nil, err
+	}
+
+	if m.Cols != b.Cols {
+		return nil, dimensionError(m, b)
+	}
+
+	// X A = B is the same as A^T X^T = B^T
+	x, err := solve(transpose(m.Data), transpose(b.Data))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMatrix(transpose(x), false)
+}
+
+// solves A X B = C, where A is the matrix
+func (m *Matrix) SolveSandwich(b, c *Matrix) (*Matrix, error) {
+	if err := b.checkSquare(); err != nil {
+		return nil, err
+	}
+
+	// A Y = C with Y = X B
+	y, err := m.SolveLeft(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.SolveRight(y)
+}
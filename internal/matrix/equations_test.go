@@ -0,0 +1,110 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+)
+
+// rounds values to hide the error of the elimination
+func roundMatrix(matrix [][]float64) [][]float64 {
+	for _, row := range matrix {
+		for j := range row {
+			row[j] = math.Round(row[j]*1e9) / 1e9
+		}
+	}
+	return matrix
+}
+
+func TestSolveLeft(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    [][]float64
+		want    [][]float64
+		wantErr bool
+	}{
+		{"square", [][]float64{{2, 1}, {1, 1}}, [][]float64{{5, 8}, {4, 6}}, [][]float64{{1, 2}, {3, 4}}, false},
+		{"column", [][]float64{{0, 1}, {1, 0}}, [][]float64{{3}, {7}}, [][]float64{{7}, {3}}, false},
+		{"identity", matrix9, [][]float64{{1, 2, 3}}, [][]float64{{1, 2, 3}}, false},
+		{"singular", matrix6, matrix8, nil, true},
+		{"not square", matrix5, matrix9, nil, true},
+		{"dimensions", [][]float64{{2, 1}, {1, 1}}, matrix8, nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				a, _ := NewMatrix(test.a, false)
+				b, _ := NewMatrix(test.b, false)
+				ans, err := a.SolveLeft(b)
+				if (err != nil) != test.wantErr {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if err != nil {
+					return
+				}
+
+				got := MatrixToString(roundMatrix(ans.Data), " ")
+				want := MatrixToString(test.want, " ")
+				if got != want {
+					t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+				}
+			})
+	}
+}
+
+func TestSolveRight(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    [][]float64
+		want    [][]float64
+		wantErr bool
+	}{
+		{"square", [][]float64{{2, 1}, {1, 1}}, [][]float64{{4, 3}, {10, 7}}, [][]float64{{1, 2}, {3, 4}}, false},
+		{"row", [][]float64{{0, 1}, {1, 0}}, [][]float64{{3, 7}}, [][]float64{{7, 3}}, false},
+		{"singular", matrix6, matrix5, nil, true},
+		{"dimensions", [][]float64{{2, 1}, {1, 1}}, matrix5, nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				a, _ := NewMatrix(test.a, false)
+				b, _ := NewMatrix(test.b, false)
+				ans, err := a.SolveRight(b)
+				if (err != nil) != test.wantErr {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if err != nil {
+					return
+				}
+
+				got := MatrixToString(roundMatrix(ans.Data), " ")
+				want := MatrixToString(test.want, " ")
+				if got != want {
+					t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+				}
+			})
+	}
+}
+
+func TestSolveSandwich(t *testing.T) {
+	a, _ := NewMatrix([][]float64{{2, 1}, {1, 1}}, false)
+	b, _ := NewMatrix([][]float64{{1, 1}, {0, 1}}, false)
+	c, _ := NewMatrix([][]float64{{5, 13}, {4, 10}}, false)
+
+	ans, err := a.SolveSandwich(b, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := MatrixToString(roundMatrix(ans.Data), " ")
+	want := MatrixToString([][]float64{{1, 2}, {3, 4}}, " ")
+	if got != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+
+	singular, _ := NewMatrix([][]float64{{1, 1}, {1, 1}}, false)
+	if _, err := a.SolveSandwich(singular, c); err == nil {
+		t.Error("expected error for singular matrix")
+	}
+}
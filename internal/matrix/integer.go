@@ -0,0 +1,377 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// matrix of arbitrary precision integers for exact lattice computations
+type IntegerMatrix struct {
+	Data      [][]*big.Int
+	Rows      int
+	Cols      int
+	Augmented bool
+}
+
+// converts real values to integers. values should be integers
+func NewIntegerMatrix(data [][]float64, augmented bool) (*IntegerMatrix, error) {
+	if data == nil || data[0] == nil {
+		return nil, errors.New("error: data is nil")
+	}
+
+	rows, cols := len(data), len(data[0])
+	if rows == 0 || cols == 0 {
+		return nil, errors.New("error: data is empty")
+	}
+
+	if augmented && cols < 2 {
+		return nil, errors.New("augmented matrix should have at least 2 columns")
+	}
+
+	matrix := newIntegers(rows, cols)
+	for i := range rows {
+		if len(data[i]) != cols {
+			return nil, errors.New("error: data is not rectangle-shaped")
+		}
+
+		for j, value := range data[i] {
+			if value != math.Trunc(value) || math.IsInf(value, 0) {
+				return nil, fmt.Errorf("value %v at (%d, %d) is not an integer", value, i+1, j+1)
+			}
+			big.NewFloat(value).Int(matrix[i][j])
+		}
+	}
+
+	return &IntegerMatrix{
+		Data:      matrix,
+		Rows:      rows,
+		Cols:      cols,
+		Augmented: augmented,
+	}, nil
+}
+
+func newIntegers(rows, cols int) [][]*big.Int {
+	matrix := make([][]*big.Int, rows)
+	for i := range rows {
+		matrix[i] = make([]*big.Int, cols)
+		for j := range cols {
+			matrix[i][j] = new(big.Int)
+		}
+	}
+	return matrix
+}
+
+func identityIntegers(n int) [][]*big.Int {
+	identity := newIntegers(n, n)
+	for i := range n {
+		identity[i][i].SetInt64(1)
+	}
+	return identity
+}
+
+// copies first cols columns of the matrix
+func cloneIntegers(matrix [][]*big.Int, cols int) [][]*big.Int {
+	clone := newIntegers(len(matrix), cols)
+	for i := range matrix {
+		for j := range cols {
+			clone[i][j].Set(matrix[i][j])
+		}
+	}
+	return clone
+}
+
+// row dst -= q * row src
+func subRowMultiple(matrix [][]*big.Int, dst, src int, q *big.Int) {
+	product := new(big.Int)
+	for j := range matrix[dst] {
+		matrix[dst][j].Sub(matrix[dst][j], product.Mul(q, matrix[src][j]))
+	}
+}
+
+// col dst -= q * col src
+func subColMultiple(matrix [][]*big.Int, dst, src int, q *big.Int) {
+	product := new(big.Int)
+	for i := range matrix {
+		matrix[i][dst].Sub(matrix[i][dst], product.Mul(q, matrix[i][src]))
+	}
+}
+
+func swapIntegerCols(matrix [][]*big.Int, i, j int) {
+	for _, row := range matrix {
+		row[i], row[j] = row[j], row[i]
+	}
+}
+
+func negateRow(matrix [][]*big.Int, row int) {
+	for _, value := range matrix[row] {
+		value.Neg(value)
+	}
+}
+
+func (m *IntegerMatrix) coefficients() [][]*big.Int {
+	if !m.Augmented {
+		return cloneIntegers(m.Data, m.Cols)
+	}
+
+	return cloneIntegers(m.Data, m.Cols-1)
+}
+
+// smith normal form D = U A V, where U and V are unimodular and
+// D is diagonal with d1 | d2 | ... | dr, all of them positive
+type SmithForm struct {
+	D [][]*big.Int
+	U [][]*big.Int
+	V [][]*big.Int
+}
+
+// returns smith normal form of the matrix (of the coefficients, if augmented)
+func (m *IntegerMatrix) Smith() *SmithForm {
+	d := m.coefficients()
+	rows, cols := len(d), len(d[0])
+	u, v := identityIntegers(rows), identityIntegers(cols)
+
+	q := new(big.Int)
+	remainder := new(big.Int)
+
+	for t := 0; t < min(rows, cols); t++ {
+		for {
+			// the smallest non-zero value of the remaining block becomes the pivot
+			pi, pj := -1, -1
+			for i := t; i < rows; i++ {
+				for j := t; j < cols; j++ {
+					if d[i][j].Sign() != 0 && (pi == -1 || d[i][j].CmpAbs(d[pi][pj]) < 0) {
+						pi, pj = i, j
+					}
+				}
+			}
+
+			if pi == -1 {
+				return &SmithForm{D: d, U: u, V: v}
+			}
+
+			d[t], d[pi] = d[pi], d[t]
+			u[t], u[pi] = u[pi], u[t]
+			swapIntegerCols(d, t, pj)
+			swapIntegerCols(v, t, pj)
+
+			done := true
+			for i := t + 1; i < rows; i++ {
+				q.Quo(d[i][t], d[t][t])
+				subRowMultiple(d, i, t, q)
+				subRowMultiple(u, i, t, q)
+				if d[i][t].Sign() != 0 {
+					done = false
+				}
+			}
+
+			for j := t + 1; j < cols; j++ {
+				q.Quo(d[t][j], d[t][t])
+				subColMultiple(d, j, t, q)
+				subColMultiple(v, j, t, q)
+				if d[t][j].Sign() != 0 {
+					done = false
+				}
+			}
+
+			if !done {
+				continue
+			}
+
+			// pivot should divide the whole remaining block. if it does not,
+			// adding the row gives a smaller remainder on the next pass
+			for i := t + 1; i < rows && done; i++ {
+				for j := t + 1; j < cols; j++ {
+					if remainder.Rem(d[i][j], d[t][t]).Sign() != 0 {
+						subRowMultiple(d, t, i, big.NewInt(-1))
+						subRowMultiple(u, t, i, big.NewInt(-1))
+						done = false
+						break
+					}
+				}
+			}
+
+			if done {
+				break
+			}
+		}
+
+		if d[t][t].Sign() < 0 {
+			negateRow(d, t)
+			negateRow(u, t)
+		}
+	}
+
+	return &SmithForm{D: d, U: u, V: v}
+}
+
+// row-style hermite normal form H = U A, where U is unimodular and
+// H is in echelon form with positive pivots and values above each
+// pivot reduced to [0, pivot)
+type HermiteForm struct {
+	H [][]*big.Int
+	U [][]*big.Int
+}
+
+// returns hermite normal form of the matrix (of the coefficients, if augmented)
+func (m *IntegerMatrix) Hermite() *HermiteForm {
+	h := m.coefficients()
+	rows, cols := len(h), len(h[0])
+	u := identityIntegers(rows)
+
+	q := new(big.Int)
+
+	for r, k := 0, 0; r < rows && k < cols; k++ {
+		for {
+			pivot := -1
+			for i := r; i < rows; i++ {
+				if h[i][k].Sign() != 0 && (pivot == -1 || h[i][k].CmpAbs(h[pivot][k]) < 0) {
+					pivot = i
+				}
+			}
+
+			if pivot == -1 {
+				break
+			}
+
+			h[r], h[pivot] = h[pivot], h[r]
+			u[r], u[pivot] = u[pivot], u[r]
+
+			done := true
+			for i := r + 1; i < rows; i++ {
+				q.Quo(h[i][k], h[r][k])
+				subRowMultiple(h, i, r, q)
+				subRowMultiple(u, i, r, q)
+				if h[i][k].Sign() != 0 {
+					done = false
+				}
+			}
+
+			if done {
+				break
+			}
+		}
+
+		if h[r][k].Sign() == 0 {
+			continue
+		}
+
+		if h[r][k].Sign() < 0 {
+			negateRow(h, r)
+			negateRow(u, r)
+		}
+
+		// euclidean division keeps remainders non-negative
+		for i := range r {
+			q.Div(h[i][k], h[r][k])
+			subRowMultiple(h, i, r, q)
+			subRowMultiple(u, i, r, q)
+		}
+
+		r++
+	}
+
+	return &HermiteForm{H: h, U: u}
+}
+
+// general integer solution x = Particular + t1*Kernel[0] + ... + tk*Kernel[k-1]
+type DiophantineSolution struct {
+	Exists     bool
+	Particular []*big.Int
+	Kernel     [][]*big.Int
+}
+
+// solves augmented matrix in integers using smith normal form:
+// A x = b turns into D y = U b with x = V y
+func (m *IntegerMatrix) SolveDiophantine() (*DiophantineSolution, error) {
+	if !m.Augmented {
+		return nil, errors.New("matrix is not augmented")
+	}
+
+	smith := m.Smith()
+	rows, cols := m.Rows, m.Cols-1
+
+	c := make([]*big.Int, rows)
+	for i := range rows {
+		c[i] = new(big.Int)
+		for k := range rows {
+			c[i].Add(c[i], new(big.Int).Mul(smith.U[i][k], m.Data[k][cols]))
+		}
+	}
+
+	rank := 0
+	for rank < min(rows, cols) && smith.D[rank][rank].Sign() != 0 {
+		rank++
+	}
+
+	y := make([]*big.Int, cols)
+	for j := range cols {
+		y[j] = new(big.Int)
+	}
+
+	remainder := new(big.Int)
+	for i := range rows {
+		if i >= rank {
+			if c[i].Sign() != 0 {
+				return &DiophantineSolution{}, nil
+			}
+			continue
+		}
+
+		y[i].QuoRem(c[i], smith.D[i][i], remainder)
+		if remainder.Sign() != 0 {
+			return &DiophantineSolution{}, nil
+		}
+	}
+
+	solution := &DiophantineSolution{Exists: true, Particular: make([]*big.Int, cols)}
+	for i := range cols {
+		solution.Particular[i] = new(big.Int)
+		for j := range rank {
+			solution.Particular[i].Add(solution.Particular[i], new(big.Int).Mul(smith.V[i][j], y[j]))
+		}
+	}
+
+	for j := rank; j < cols; j++ {
+		vector := make([]*big.Int, cols)
+		for i := range cols {
+			vector[i] = new(big.Int).Set(smith.V[i][j])
+		}
+		solution.Kernel = append(solution.Kernel, vector)
+	}
+
+	return solution, nil
+}
+
+func formatIntegers(vector []*big.Int) string {
+	values := make([]string, len(vector))
+	for i, value := range vector {
+		values[i] = value.String()
+	}
+	return "[" + strings.Join(values, " ") + "]"
+}
+
+func (s *DiophantineSolution) String() string {
+	if !s.Exists {
+		return "no integer solutions"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("x = " + formatIntegers(s.Particular))
+	for i, vector := range s.Kernel {
+		fmt.Fprintf(&sb, " + t%d*%s", i+1, formatIntegers(vector))
+	}
+
+	return sb.String()
+}
+
+func (m *IntegerMatrix) String() string {
+	rows := make([]string, m.Rows)
+	for i, row := range m.Data {
+		rows[i] = formatIntegers(row)
+	}
+
+	return strings.Join(rows, "\n")
+}
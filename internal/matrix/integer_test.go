@@ -0,0 +1,165 @@
+package matrix
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func integersToString(matrix [][]*big.Int) string {
+	var sb strings.Builder
+	for _, row := range matrix {
+		sb.WriteString(formatIntegers(row) + "\n")
+	}
+	return sb.String()
+}
+
+func multiplyIntegers(a, b [][]*big.Int) [][]*big.Int {
+	product := newIntegers(len(a), len(b[0]))
+	for i := range a {
+		for j := range b[0] {
+			for k := range b {
+				product[i][j].Add(product[i][j], new(big.Int).Mul(a[i][k], b[k][j]))
+			}
+		}
+	}
+	return product
+}
+
+func TestSmith(t *testing.T) {
+	tests := []struct {
+		name   string
+		matrix [][]float64
+		want   string
+	}{
+		{"coprime diagonal", [][]float64{{2, 0}, {0, 3}}, "[1 0]\n[0 6]\n"},
+		{"2x2", [][]float64{{1, 2}, {3, 4}}, "[1 0]\n[0 2]\n"},
+		{"singular", [][]float64{{4, 6}, {6, 9}}, "[1 0]\n[0 0]\n"},
+		{"row", [][]float64{{2, 4, 6}}, "[2 0 0]\n"},
+		{"3x3", [][]float64{{2, 4, 4}, {-6, 6, 12}, {10, -4, -16}}, "[2 0 0]\n[0 6 0]\n[0 0 12]\n"},
+		{"negative", [][]float64{{-3}}, "[3]\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				matrix, err := NewIntegerMatrix(test.matrix, false)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				smith := matrix.Smith()
+				if ans := integersToString(smith.D); ans != test.want {
+					t.Errorf("\ngot:\n%s\nwant:\n%s", ans, test.want)
+				}
+
+				product := multiplyIntegers(multiplyIntegers(smith.U, matrix.Data), smith.V)
+				if ans := integersToString(product); ans != test.want {
+					t.Errorf("UAV:\ngot:\n%s\nwant:\n%s", ans, test.want)
+				}
+			})
+	}
+}
+
+func TestHermite(t *testing.T) {
+	tests := []struct {
+		name   string
+		matrix [][]float64
+		want   string
+	}{
+		{"3x4", [][]float64{{2, 3, 6, 2}, {5, 6, 1, 6}, {8, 3, 1, 1}}, "[1 0 50 -11]\n[0 3 28 -2]\n[0 0 61 -13]\n"},
+		{"upper", [][]float64{{3, 3, 1, 4}, {0, 1, 0, 0}, {0, 0, 19, 16}, {0, 0, 0, 3}}, "[3 0 1 1]\n[0 1 0 0]\n[0 0 19 1]\n[0 0 0 3]\n"},
+		{"dependent", [][]float64{{2, 4}, {3, 6}}, "[1 2]\n[0 0]\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				matrix, err := NewIntegerMatrix(test.matrix, false)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				hermite := matrix.Hermite()
+				if ans := integersToString(hermite.H); ans != test.want {
+					t.Errorf("\ngot:\n%s\nwant:\n%s", ans, test.want)
+				}
+
+				product := multiplyIntegers(hermite.U, matrix.Data)
+				if ans := integersToString(product); ans != test.want {
+					t.Errorf("UA:\ngot:\n%s\nwant:\n%s", ans, test.want)
+				}
+			})
+	}
+}
+
+func TestSolveDiophantine(t *testing.T) {
+	tests := []struct {
+		name   string
+		matrix [][]float64
+		exists bool
+		kernel int
+	}{
+		{"unique", [][]float64{{1, 1, 3}, {1, -1, 1}}, true, 0},
+		{"one equation", [][]float64{{2, 4, 6}}, true, 1},
+		{"odd right side", [][]float64{{2, 4, 5}}, false, 0},
+		{"rational only", [][]float64{{2, 0, 1}, {0, 1, 1}}, false, 0},
+		{"inconsistent", [][]float64{{1, 1, 1}, {2, 2, 3}}, false, 0},
+		{"dependent rows", [][]float64{{3, 6, 9}, {2, 4, 6}}, true, 1},
+		{"three unknowns", [][]float64{{1, 2, 3, 7}}, true, 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				matrix, err := NewIntegerMatrix(test.matrix, true)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				solution, err := matrix.SolveDiophantine()
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if solution.Exists != test.exists || len(solution.Kernel) != test.kernel {
+					t.Fatalf("got %s", solution)
+				}
+
+				if !solution.Exists {
+					return
+				}
+
+				a := matrix.coefficients()
+				for i := range matrix.Rows {
+					sum := new(big.Int)
+					for j, x := range solution.Particular {
+						sum.Add(sum, new(big.Int).Mul(a[i][j], x))
+					}
+					if sum.Cmp(matrix.Data[i][matrix.Cols-1]) != 0 {
+						t.Errorf("particular solution %s does not satisfy row %d", solution, i+1)
+					}
+
+					for _, vector := range solution.Kernel {
+						sum.SetInt64(0)
+						for j, x := range vector {
+							sum.Add(sum, new(big.Int).Mul(a[i][j], x))
+						}
+						if sum.Sign() != 0 {
+							t.Errorf("kernel vector %s is not in the kernel", formatIntegers(vector))
+						}
+					}
+				}
+			})
+	}
+
+	matrix, _ := NewIntegerMatrix([][]float64{{1, 1, 3}, {1, -1, 1}}, true)
+	solution, _ := matrix.SolveDiophantine()
+	if ans := solution.String(); ans != "x = [2 1]" {
+		t.Errorf("got %q, want %q", ans, "x = [2 1]")
+	}
+
+	if _, err := NewIntegerMatrix([][]float64{{1.5}}, false); err == nil {
+		t.Error("expected error for non-integer value")
+	}
+}
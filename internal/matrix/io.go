@@ -2,106 +2,601 @@ package matrix
 
 import (
 	"bufio"
-	"bytes"
 	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
-func ReadSlow(path string) ([][]float64, error) {
-	rows, cols, err := getRowsCols(path)
+// error in the input of Read. line and column are counted from 1,
+// column is counted in characters
+type ParseError struct {
+	Line   int
+	Column int
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %v", e.Line, e.Column, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// separates coefficients from the right-hand side in augmented matrices
+const augmentedSeparator = "|"
+
+// starts a comment, the rest of the line is skipped
+const commentPrefix = "#"
+
+// metadata from comments before the first row, like "# name: A"
+type header struct {
+	name      string
+	augmented bool
+	precision int
+}
+
+// reads matrix with values separated by whitespace, a row per line.
+// input is read once, lines can be of any length. values can be
+// fractions like "-2/5". blank lines and comments after "#" are skipped.
+// the last value can be separated with "|", like in "1 2 | 3",
+// then it should be in every row
+func Read(r io.Reader) ([][]float64, error) {
+	matrix, _, err := read(r, parseFloat)
+	return matrix, err
+}
+
+// same as Read, but values can be complex: "3", "4i", "3+4i"
+func ReadComplex(r io.Reader) ([][]complex128, error) {
+	matrix, _, err := read(r, ParseComplex)
+	return matrix, err
+}
+
+// same as Read, but the matrix is augmented if rows have the separator.
+// comments before the first row can set "name", "augmented" and "precision"
+func ReadMatrix(r io.Reader) (*Matrix, error) {
+	data, h, err := read(r, parseFloat)
 	if err != nil {
 		return nil, err
 	}
 
-	file, err := os.Open(path)
+	m, err := NewMatrix(data, h.augmented)
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
 
-	matrix, memory, _ := Malloc[float64](rows, cols)
-	scanner := bufio.NewScanner(file)
-	for i := range rows {
-		matrix[i] = memory[(i * cols) : (i+1)*cols]
-		scanner.Scan()
-		fields := strings.Fields(scanner.Text())
-		for j, field := range fields {
-			matrix[i][j], _ = strconv.ParseFloat(field, 64)
+	m.Name, m.Precision = h.name, h.precision
+	return m, nil
+}
+
+// same as ReadMatrix, but values can be complex
+func ReadComplexMatrix(r io.Reader) (*ComplexMatrix, error) {
+	data, h, err := read(r, ParseComplex)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := NewComplexMatrix(data, h.augmented)
+	if err != nil {
+		return nil, err
+	}
+
+	m.Name, m.Precision = h.name, h.precision
+	return m, nil
+}
+
+// extensions of the formats with only real values
+var realFormats = []string{".json", ".yaml", ".yml", ".mtx", ".npy", ".npz"}
+
+// reports whether the file by name can have only real values
+func IsRealFormat(name string) bool {
+	return slices.Contains(realFormats, strings.ToLower(filepath.Ext(name)))
+}
+
+// reads matrix in the format of the file name extension: ".json" and
+// ".yaml" with ReadJSON and ReadYAML, ".mtx" with ReadMatrixMarket,
+// ".npy" and ".npz" with ReadNPY and ReadNPZ, ".csv" and ".tsv" with
+// ReadCSV. other files are read with ReadMatrix
+func ReadFile(name string, r io.Reader) (*Matrix, error) {
+	extension := strings.ToLower(filepath.Ext(name))
+
+	var data [][]float64
+	var err error
+	switch extension {
+	case ".json":
+		return ReadJSON(r)
+	case ".yaml", ".yml":
+		return ReadYAML(r)
+	case ".mtx":
+		data, err = ReadMatrixMarket(r)
+	case ".npy":
+		data, err = ReadNPY(r)
+	case ".npz":
+		return readNPZMatrix(r)
+	default:
+		delimiter, ok := CSVDelimiter(extension)
+		if !ok {
+			return ReadMatrix(r)
 		}
+		data, _, err = ReadCSV(r, delimiter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMatrix(data, false)
+}
+
+// reads the only matrix of .npz archive, archives with
+// several matrices should be read with ReadNPZ
+func readNPZMatrix(r io.Reader) (*Matrix, error) {
+	matrices, err := ReadNPZ(r)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(matrices))
+	for name := range matrices {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	if len(names) > 1 {
+		return nil, fmt.Errorf(".npz archive has %d arrays, expected one: %s", len(names), strings.Join(names, ", "))
+	}
+
+	matrix, err := NewMatrix(matrices[names[0]], false)
+	if err != nil {
+		return nil, err
 	}
 
+	matrix.Name = names[0]
 	return matrix, nil
 }
 
-func Write[number Number](path string, matrix [][]number) error {
-	file, err := os.Create(path)
+// same as ReadFile, but values can be complex
+func ReadComplexFile(name string, r io.Reader) (*ComplexMatrix, error) {
+	if IsRealFormat(name) {
+		matrix, err := ReadFile(name, r)
+		if err != nil {
+			return nil, err
+		}
+		return NewComplexMatrixFromReal(matrix)
+	}
+
+	delimiter, ok := CSVDelimiter(filepath.Ext(name))
+	if !ok {
+		return ReadComplexMatrix(r)
+	}
+
+	data, _, err := ReadComplexCSV(r, delimiter)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return NewComplexMatrix(data, false)
+}
 
-	defer file.Close()
+// writes matrix in the format of the file name extension, like ReadFile.
+// matrix is saved in .npz archive by its name or as "matrix"
+func WriteFile(name string, w io.Writer, m *Matrix) error {
+	extension := strings.ToLower(filepath.Ext(name))
+	switch extension {
+	case ".json":
+		return WriteJSON(w, m)
+	case ".yaml", ".yml":
+		return WriteYAML(w, m)
+	case ".mtx":
+		return WriteMatrixMarket(w, m.Data, false)
+	case ".npy":
+		return WriteNPY(w, m.Data)
+	case ".npz":
+		name := m.Name
+		if name == "" {
+			name = "matrix"
+		}
+		return WriteNPZ(w, map[string][][]float64{name: m.Data})
+	}
 
-	file.WriteString(MatrixToString(matrix, " "))
+	if delimiter, ok := CSVDelimiter(extension); ok {
+		return WriteCSV(w, m.Data, nil, delimiter)
+	}
+	return WriteMatrix(w, m)
+}
 
-	return nil
+// same as WriteFile, but values can be complex. formats
+// with only real values are not supported
+func WriteComplexFile(name string, w io.Writer, m *ComplexMatrix) error {
+	if IsRealFormat(name) {
+		return fmt.Errorf("only real matrices can be written to %s files", strings.ToLower(filepath.Ext(name)))
+	}
+
+	if delimiter, ok := CSVDelimiter(filepath.Ext(name)); ok {
+		return WriteCSV(w, m.Data, nil, delimiter)
+	}
+	return WriteComplexMatrix(w, m)
 }
 
-func getLinesCount(path string) (int, error) {
+// same as ReadFile, but opens the file by path
+func ReadSlow(path string) ([][]float64, error) {
 	file, err := os.Open(path)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 	defer file.Close()
 
-	buffer := make([]byte, 1024)
-	count := 0
-	separator := []byte{'\n'}
-
-	for {
-		n, err := file.Read(buffer)
-		count += bytes.Count(buffer[:n], separator)
-
-		switch {
-		case err == io.EOF:
-			return count, nil
-		case err != nil:
-			return count, err
-		}
+	matrix, err := ReadFile(path, file)
+	if err != nil {
+		return nil, err
 	}
+	return matrix.Data, nil
 }
 
-func getRowsCols(path string) (int, int, error) {
+// same as ReadComplexFile, but opens the file by path
+func ReadComplexSlow(path string) ([][]complex128, error) {
 	file, err := os.Open(path)
 	if err != nil {
-		return 0, 0, err
+		return nil, err
 	}
 	defer file.Close()
 
+	matrix, err := ReadComplexFile(path, file)
+	if err != nil {
+		return nil, err
+	}
+	return matrix.Data, nil
+}
+
+// parses decimal values and fractions like "-2/5" or "1e3/7"
+func parseFloat(field string) (float64, error) {
+	numerator, denominator, ok := strings.Cut(field, "/")
+	if !ok {
+		return strconv.ParseFloat(field, 64)
+	}
+
+	n, err := strconv.ParseFloat(numerator, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	d, err := strconv.ParseFloat(denominator, 64)
+	if err != nil {
+		return 0, err
+	}
+	if d == 0 {
+		return 0, errors.New("division by zero")
+	}
+
+	return n / d, nil
+}
+
+// parses "key: value" comment into the header. other comments are ignored
+func (h *header) parse(comment string, augmentedSet *bool) error {
+	key, value, ok := strings.Cut(comment, ":")
+	if !ok {
+		return nil
+	}
+	value = strings.TrimSpace(value)
+
+	switch strings.ToLower(strings.TrimSpace(key)) {
+	case "name":
+		h.name = value
+	case "augmented":
+		augmented, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("augmented should be true or false, got %q", value)
+		}
+		h.augmented, *augmentedSet = augmented, true
+	case "precision":
+		precision, err := strconv.Atoi(value)
+		if err != nil || precision < 0 {
+			return fmt.Errorf("precision should be a non-negative integer, got %q", value)
+		}
+		h.precision = precision
+	}
+
+	return nil
+}
+
+// splits line into fields and their columns counted from 1.
+// separator is a field even without spaces around it
+func splitFields(line string) ([]string, []int) {
+	var fields []string
+	var columns []int
+
+	start, column := -1, 0
+	for i, r := range line {
+		column++
+		if unicode.IsSpace(r) || string(r) == augmentedSeparator {
+			if start != -1 {
+				fields = append(fields, line[start:i])
+				start = -1
+			}
+
+			if string(r) == augmentedSeparator {
+				fields = append(fields, augmentedSeparator)
+				columns = append(columns, column)
+			}
+			continue
+		}
+
+		if start == -1 {
+			start = i
+			columns = append(columns, column)
+		}
+	}
+
+	if start != -1 {
+		fields = append(fields, line[start:])
+	}
+
+	return fields, columns
+}
+
+// reads matrix and the header. the matrix is augmented
+// if the header says so or its rows have the separator
+func read[value any](r io.Reader, parse func(string) (value, error)) ([][]value, header, error) {
+	reader := bufio.NewReader(r)
+
+	var memory []value
+	var h header
 	rows, cols := 0, 0
+	augmentedSet, separated := false, false
+	for line := 1; ; line++ {
+		text, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, h, err
+		}
+
+		// the last line without values is the end of the file
+		if err == io.EOF && text == "" {
+			break
+		}
+
+		content, comment, commented := strings.Cut(strings.TrimRight(text, "\r\n"), commentPrefix)
+		fields, columns := splitFields(content)
+		if len(fields) == 0 {
+			if commented && rows == 0 {
+				column := utf8.RuneCountInString(content) + 1
+				if headerErr := h.parse(comment, &augmentedSet); headerErr != nil {
+					return nil, h, &ParseError{line, column, headerErr}
+				}
+			}
+
+			if err == io.EOF {
+				break
+			}
+			continue
+		}
+
+		// end of the values in the line
+		end := utf8.RuneCountInString(strings.TrimRightFunc(content, unicode.IsSpace)) + 1
+
+		separator, separatorColumn := slices.Index(fields, augmentedSeparator), 0
+		if separator != -1 {
+			separatorColumn = columns[separator]
+			if separator == 0 || separator != len(fields)-2 {
+				return nil, h, &ParseError{line, separatorColumn, errors.New("separator should be before the last value")}
+			}
+
+			fields = slices.Delete(fields, separator, separator+1)
+			columns = slices.Delete(columns, separator, separator+1)
+		}
 
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		fields := strings.Fields(scanner.Text())
-		if cols == 0 {
-			cols = len(fields)
-		} else if cols != len(fields) {
-			return 0, 0, errors.New("file is not a matrix")
+		if rows == 0 {
+			cols, separated = len(fields), separator != -1
+			if augmentedSet && !h.augmented && separated {
+				return nil, h, &ParseError{line, separatorColumn, errors.New("unexpected separator, matrix is not augmented")}
+			}
+			h.augmented = h.augmented || separated
 		}
 
+		if len(fields) != cols {
+			// points to the first extra value or to the end of the short line
+			column := end
+			if len(fields) > cols {
+				column = columns[cols]
+			}
+			return nil, h, &ParseError{line, column, fmt.Errorf("row has %d values, expected %d", len(fields), cols)}
+		}
+
+		if separated && separator == -1 {
+			return nil, h, &ParseError{line, columns[cols-1], errors.New("separator is missing")}
+		}
+		if !separated && separator != -1 {
+			return nil, h, &ParseError{line, separatorColumn, errors.New("unexpected separator, first row has none")}
+		}
+
+		for j, field := range fields {
+			parsed, parseErr := parse(field)
+			if parseErr != nil {
+				return nil, h, &ParseError{line, columns[j], fmt.Errorf("%q is not a number", field)}
+			}
+			memory = append(memory, parsed)
+		}
 		rows++
+
+		if err == io.EOF {
+			break
+		}
+	}
+
+	if rows == 0 {
+		return nil, h, errors.New("error: data is empty")
+	}
+
+	matrix := make([][]value, rows)
+	for i := range matrix {
+		matrix[i] = memory[(i * cols) : (i+1)*cols]
+	}
+
+	return matrix, h, nil
+}
+
+// rounds value to the precision digits after the point, 0 keeps it as is
+func round[number Number](value number, precision int) number {
+	if precision == 0 {
+		return value
+	}
+
+	roundFloat := func(x float64) float64 {
+		rounded, _ := strconv.ParseFloat(strconv.FormatFloat(x, 'f', precision, 64), 64)
+		return rounded
+	}
+
+	switch v := any(value).(type) {
+	case float64:
+		return any(roundFloat(v)).(number)
+	case complex128:
+		return any(complex(roundFloat(real(v)), roundFloat(imag(v)))).(number)
+	default:
+		return value
+	}
+}
+
+// writes the header and rows of values separated by spaces.
+// the last value of augmented matrix is separated with " | "
+func writeRows[number Number](w io.Writer, matrix [][]number, h header) error {
+	cells := make([][]string, len(matrix))
+	for i, row := range matrix {
+		cells[i] = make([]string, len(row))
+		for j, value := range row {
+			cells[i][j] = FormatNumber(round(value, h.precision))
+		}
 	}
 
-	return rows, cols, nil
+	return writeCells(w, cells, h)
+}
+
+func writeCells(w io.Writer, cells [][]string, h header) error {
+	var sb strings.Builder
+	if h.name != "" {
+		fmt.Fprintf(&sb, "%s name: %s\n", commentPrefix, h.name)
+	}
+	if h.precision != 0 {
+		fmt.Fprintf(&sb, "%s precision: %d\n", commentPrefix, h.precision)
+	}
+
+	for _, row := range cells {
+		if h.augmented {
+			sb.WriteString(strings.Join(row[:len(row)-1], " "))
+			sb.WriteString(" " + augmentedSeparator + " ")
+			sb.WriteString(row[len(row)-1])
+		} else {
+			sb.WriteString(strings.Join(row, " "))
+		}
+		sb.WriteByte('\n')
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// same as ReadMatrix, but values are not parsed. cells should not
+// have spaces, like values of symbolic matrices "2a-1" or "1/(b+1)"
+func ReadCells(r io.Reader) ([][]string, bool, error) {
+	cells, h, err := read(r, func(field string) (string, error) {
+		return field, nil
+	})
+	return cells, h.augmented, err
+}
+
+// writes cells in the format of ReadCells
+func WriteCells(w io.Writer, cells [][]string, augmented bool) error {
+	return writeCells(w, cells, header{augmented: augmented})
+}
+
+// writes matrix in the format of ReadMatrix
+func WriteMatrix(w io.Writer, m *Matrix) error {
+	return writeRows(w, m.Data, header{m.Name, m.Augmented, m.Precision})
+}
+
+// writes matrix in the format of ReadComplexMatrix
+func WriteComplexMatrix(w io.Writer, m *ComplexMatrix) error {
+	return writeRows(w, m.Data, header{m.Name, m.Augmented, m.Precision})
+}
+
+// writes matrix to the file by path in the format of its extension, like
+// WriteFile. integer matrices are written to ".npy" files as integers
+func Write[number Number](path string, matrix [][]number) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	npy := strings.ToLower(filepath.Ext(path)) == ".npy"
+
+	var data [][]float64
+	switch matrix := any(matrix).(type) {
+	case [][]complex128:
+		m, err := NewComplexMatrix(matrix, false)
+		if err != nil {
+			return err
+		}
+		return WriteComplexFile(path, file, m)
+	case [][]int:
+		if npy {
+			return WriteNPY(file, matrix)
+		}
+		data = toFloat(matrix)
+	case [][]int64:
+		if npy {
+			return WriteNPY(file, matrix)
+		}
+		data = toFloat(matrix)
+	case [][]float64:
+		data = matrix
+	}
+
+	m, err := NewMatrix(data, false)
+	if err != nil {
+		return err
+	}
+	return WriteFile(path, file, m)
+}
+
+func toFloat[integer int | int64](matrix [][]integer) [][]float64 {
+	if len(matrix) == 0 {
+		return nil
+	}
+
+	rows, cols := len(matrix), len(matrix[0])
+
+	newMatrix, memory, _ := Malloc[float64](rows, cols)
+	for i := range newMatrix {
+		newMatrix[i] = memory[(i * cols):((i + 1) * cols)]
+		for j, value := range matrix[i] {
+			newMatrix[i][j] = float64(value)
+		}
+	}
+
+	return newMatrix
+}
+
+// formats value like fmt.Sprint, but complex values are written as "3+4i"
+func FormatNumber[number Number](value number) string {
+	if c, ok := any(value).(complex128); ok {
+		return formatComplex(c)
+	}
+
+	return fmt.Sprint(value)
 }
 
 func ArrayToString[number Number](array []number, separator string) string {
-	return strings.Trim(
-		strings.Replace(fmt.Sprint(array), " ", separator, -1), "[]",
-	)
+	fields := make([]string, len(array))
+	for i, value := range array {
+		fields[i] = FormatNumber(value)
+	}
+
+	return strings.Join(fields, separator)
 }
 
 func MatrixToString[number Number](matrix [][]number, separator string) string {
@@ -114,7 +609,7 @@ func MatrixToString[number Number](matrix [][]number, separator string) string {
 	var sb strings.Builder
 
 	for _, row := range matrix {
-		sb.WriteString(ArrayToString(row, " "))
+		sb.WriteString(ArrayToString(row, separator))
 		sb.WriteByte('\n')
 	}
 
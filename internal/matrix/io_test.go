@@ -0,0 +1,314 @@
+package matrix
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  [][]float64
+	}{
+		{"rows", "1 2 3\n4 5 6\n", [][]float64{{1, 2, 3}, {4, 5, 6}}},
+		{"no newline", "1 2\n3 4", [][]float64{{1, 2}, {3, 4}}},
+		{"windows", "1 2\r\n3 4\r\n", [][]float64{{1, 2}, {3, 4}}},
+		{"whitespace", "\t1   -2.5 \n 3e2\t4\n", [][]float64{{1, -2.5}, {300, 4}}},
+		{"column", "1\n2\n3\n", matrix8},
+		{"blank lines", "\n1 2\n\n  \n3 4\n\n", [][]float64{{1, 2}, {3, 4}}},
+		{"comments", "# matrix\n1 2 # first\n#\n3 4#second\n", [][]float64{{1, 2}, {3, 4}}},
+		{"fractions", "1/2 -2/5\n3/-4 1e1/4\n", [][]float64{{0.5, -0.4}, {-0.75, 2.5}}},
+		{"scientific", "1E-3 -2.5e+2\n", [][]float64{{0.001, -250}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				ans, err := Read(strings.NewReader(test.input))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				got := MatrixToString(ans, " ")
+				want := MatrixToString(test.want, " ")
+				if got != want {
+					t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+				}
+			})
+	}
+}
+
+func TestReadLongLine(t *testing.T) {
+	// longer than the default limit of bufio.Scanner
+	const cols = 40000
+	line := strings.Repeat("1.5 ", cols)
+
+	ans, err := Read(strings.NewReader(line + "\n" + line + "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ans) != 2 || len(ans[0]) != cols || ans[1][cols-1] != 1.5 {
+		t.Errorf("got %d rows of %d values", len(ans), len(ans[0]))
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		want   string
+		line   int
+		column int
+	}{
+		{"bad number", "1 2\n3 x4\n", "line 2, column 3: \"x4\" is not a number", 2, 3},
+		{"short row", "1 2 3\n4 5\n", "line 2, column 4: row has 2 values, expected 3", 2, 4},
+		{"long row", "1 2\n3 4 5\n", "line 2, column 5: row has 3 values, expected 2", 2, 5},
+		{"zero denominator", "1 2\n1/0 2\n", "line 2, column 1: \"1/0\" is not a number", 2, 1},
+		{"bad fraction", "1/2/3\n", "line 1, column 1: \"1/2/3\" is not a number", 1, 1},
+		{"number in comment", "1 2\n3 4 # 5\n5\n", "line 3, column 2: row has 1 values, expected 2", 3, 2},
+		{"unicode", "1 2\nπ 2\n", "line 2, column 1: \"π\" is not a number", 2, 1},
+		{"after unicode", "1 2 3\n1 π x\n", "line 2, column 3: \"π\" is not a number", 2, 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				_, err := Read(strings.NewReader(test.input))
+				if err == nil || err.Error() != test.want {
+					t.Fatalf("got %v, want %q", err, test.want)
+				}
+
+				var parseErr *ParseError
+				if !errors.As(err, &parseErr) || parseErr.Line != test.line || parseErr.Column != test.column {
+					t.Errorf("got %#v", err)
+				}
+			})
+	}
+
+	for _, input := range []string{"", "\n\n", "# only a comment\n"} {
+		if _, err := Read(strings.NewReader(input)); err == nil {
+			t.Errorf("expected error for input %q", input)
+		}
+	}
+}
+
+func TestReadComplex(t *testing.T) {
+	ans, err := ReadComplex(strings.NewReader("3+4i -1i # comment\n4/2 (0.5-1.5i)\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := [][]complex128{{3 + 4i, -1i}, {2, 0.5 - 1.5i}}
+	if MatrixToString(ans, " ") != MatrixToString(want, " ") {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", MatrixToString(ans, " "), MatrixToString(want, " "))
+	}
+
+	if _, err := ReadComplex(strings.NewReader("1 2j\n")); err == nil {
+		t.Error("expected error for bad complex value")
+	}
+}
+
+func TestReadMatrix(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		want      [][]float64
+		augmented bool
+	}{
+		{"plain", "1 2\n3 4\n", [][]float64{{1, 2}, {3, 4}}, false},
+		{"augmented", "1 2 | 3\n4 5 | 6\n", [][]float64{{1, 2, 3}, {4, 5, 6}}, true},
+		{"no spaces", "1 2|3\n4 5|6\n", [][]float64{{1, 2, 3}, {4, 5, 6}}, true},
+		{"one equation", "2 | 4", [][]float64{{2, 4}}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				ans, err := ReadMatrix(strings.NewReader(test.input))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				got := MatrixToString(ans.Data, " ")
+				want := MatrixToString(test.want, " ")
+				if got != want {
+					t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+				}
+				if ans.Augmented != test.augmented {
+					t.Errorf("got augmented %v, want %v", ans.Augmented, test.augmented)
+				}
+			})
+	}
+}
+
+func TestReadMatrixErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"missing", "1 2 | 3\n4 5 6\n", "line 2, column 5: separator is missing"},
+		{"unexpected", "1 2 3\n4 5 | 6\n", "line 2, column 5: unexpected separator, first row has none"},
+		{"position", "1 | 2 3\n", "line 1, column 3: separator should be before the last value"},
+		{"first", "| 1\n", "line 1, column 1: separator should be before the last value"},
+		{"twice", "1 | 2 | 3\n", "line 1, column 3: separator should be before the last value"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				_, err := ReadMatrix(strings.NewReader(test.input))
+				if err == nil || err.Error() != test.want {
+					t.Errorf("got %v, want %q", err, test.want)
+				}
+			})
+	}
+}
+
+func TestReadMatrixHeader(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		want      [][]float64
+		augmented bool
+		title     string
+		precision int
+	}{
+		{"metadata", "# Name: system A\n# precision: 3\n# note: ignored\n1 2\n",
+			[][]float64{{1, 2}}, false, "system A", 3},
+		{"augmented", "# augmented: true\n1 2\n3 4\n", [][]float64{{1, 2}, {3, 4}}, true, "", 0},
+		{"with separator", "# augmented: true\n1 | 2\n", [][]float64{{1, 2}}, true, "", 0},
+		{"after data", "1 2\n# name: B\n# augmented: true\n", [][]float64{{1, 2}}, false, "", 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				ans, err := ReadMatrix(strings.NewReader(test.input))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				got := MatrixToString(ans.Data, " ")
+				want := MatrixToString(test.want, " ")
+				if got != want {
+					t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+				}
+				if ans.Augmented != test.augmented || ans.Name != test.title || ans.Precision != test.precision {
+					t.Errorf("got augmented %v, name %q, precision %d", ans.Augmented, ans.Name, ans.Precision)
+				}
+			})
+	}
+}
+
+func TestReadMatrixHeaderErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"augmented", "# augmented: maybe\n1 2\n", "line 1, column 1: augmented should be true or false, got \"maybe\""},
+		{"not a header", "1 2 # precision: -1\n", ""},
+		{"negative precision", "  # precision: -1\n1 2\n", "line 1, column 3: precision should be a non-negative integer, got \"-1\""},
+		{"separator", "# augmented: false\n1 | 2\n", "line 2, column 3: unexpected separator, matrix is not augmented"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				_, err := ReadMatrix(strings.NewReader(test.input))
+				if test.want == "" {
+					if err != nil {
+						t.Errorf("unexpected error: %v", err)
+					}
+					return
+				}
+				if err == nil || err.Error() != test.want {
+					t.Errorf("got %v, want %q", err, test.want)
+				}
+			})
+	}
+}
+
+func TestWriteMatrix(t *testing.T) {
+	for _, augmented := range []bool{false, true} {
+		matrix, _ := NewMatrix([][]float64{{1, -2.5, 3}, {4, 5, 6}}, augmented)
+
+		var sb strings.Builder
+		if err := WriteMatrix(&sb, matrix); err != nil {
+			t.Fatal(err)
+		}
+
+		want := "1 -2.5 3\n4 5 6\n"
+		if augmented {
+			want = "1 -2.5 | 3\n4 5 | 6\n"
+		}
+		if sb.String() != want {
+			t.Errorf("got %q, want %q", sb.String(), want)
+		}
+
+		ans, err := ReadMatrix(strings.NewReader(sb.String()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ans.Augmented != augmented || MatrixToString(ans.Data, " ") != MatrixToString(matrix.Data, " ") {
+			t.Errorf("matrix is not read back: %v", ans)
+		}
+	}
+
+	complexMatrix, _ := NewComplexMatrix([][]complex128{{1i, 2 + 1i}}, true)
+	var sb strings.Builder
+	WriteComplexMatrix(&sb, complexMatrix)
+	ans, err := ReadComplexMatrix(strings.NewReader(sb.String()))
+	if err != nil || !ans.Augmented || sb.String() != "1i | 2+1i\n" {
+		t.Errorf("got %q, %v", sb.String(), err)
+	}
+}
+
+func TestWriteMatrixHeader(t *testing.T) {
+	matrix, _ := NewMatrix([][]float64{{1.0 / 3, 2}, {-0.25, 4}}, true)
+	matrix.Name, matrix.Precision = "system", 2
+
+	var sb strings.Builder
+	if err := WriteMatrix(&sb, matrix); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "# name: system\n# precision: 2\n0.33 | 2\n-0.25 | 4\n"
+	if sb.String() != want {
+		t.Errorf("got %q, want %q", sb.String(), want)
+	}
+
+	ans, err := ReadMatrix(strings.NewReader(sb.String()))
+	if err != nil || ans.Name != "system" || ans.Precision != 2 || !ans.Augmented {
+		t.Errorf("header is not read back: %v, %v", ans, err)
+	}
+}
+
+func TestReadWriteFile(t *testing.T) {
+	matrix, _ := NewMatrix([][]float64{{2, 1, 5}, {1, -1, 1}}, true)
+	matrix.Name = "system"
+
+	for _, name := range []string{"system.txt", "system.JSON", "system.yaml"} {
+		var sb strings.Builder
+		if err := WriteFile(name, &sb, matrix); err != nil {
+			t.Fatal(err)
+		}
+
+		ans, err := ReadFile(name, strings.NewReader(sb.String()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ans.Name != "system" || !ans.Augmented || MatrixToString(ans.Data, " ") != MatrixToString(matrix.Data, " ") {
+			t.Errorf("%s: matrix is not read back: %+v", name, ans)
+		}
+	}
+
+	complexMatrix, _ := NewComplexMatrix([][]complex128{{1 + 2i, 3}}, false)
+	if err := WriteComplexFile("matrix.npy", io.Discard, complexMatrix); err == nil {
+		t.Error("expected error for complex .npy file")
+	}
+}
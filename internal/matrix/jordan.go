@@ -0,0 +1,536 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type JordanBlock struct {
+	Value *big.Rat
+	Size  int
+}
+
+// jordan normal form J = P^-1 A P with exact rational values.
+// blocks are ordered by eigenvalue, larger blocks go first
+type JordanForm struct {
+	J      [][]*big.Rat
+	P      [][]*big.Rat
+	Blocks []JordanBlock
+}
+
+func newRats(rows, cols int) [][]*big.Rat {
+	matrix := make([][]*big.Rat, rows)
+	for i := range matrix {
+		matrix[i] = make([]*big.Rat, cols)
+		for j := range matrix[i] {
+			matrix[i][j] = new(big.Rat)
+		}
+	}
+	return matrix
+}
+
+// converts values to rationals using their shortest decimal form
+func toRats(data [][]float64) ([][]*big.Rat, error) {
+	matrix := newRats(len(data), len(data[0]))
+	for i, row := range data {
+		for j, value := range row {
+			if _, ok := matrix[i][j].SetString(strconv.FormatFloat(value, 'g', -1, 64)); !ok {
+				return nil, fmt.Errorf("value %v at (%d, %d) is not finite", value, i+1, j+1)
+			}
+		}
+	}
+	return matrix, nil
+}
+
+func multiplyRats(a, b [][]*big.Rat) [][]*big.Rat {
+	product := newRats(len(a), len(b[0]))
+	term := new(big.Rat)
+	for i := range a {
+		for j := range b[0] {
+			for k := range b {
+				product[i][j].Add(product[i][j], term.Mul(a[i][k], b[k][j]))
+			}
+		}
+	}
+	return product
+}
+
+// reduces the copy of the matrix to the reduced row echelon form
+// with exact elimination. returns it and indices of the pivot columns
+func rrefRats(matrix [][]*big.Rat) ([][]*big.Rat, []int) {
+	rows, cols := len(matrix), len(matrix[0])
+	reduced := newRats(rows, cols)
+	for i, row := range matrix {
+		for j, value := range row {
+			reduced[i][j].Set(value)
+		}
+	}
+
+	var pivots []int
+	term := new(big.Rat)
+	for r, k := 0, 0; r < rows && k < cols; k++ {
+		pivot := slices.IndexFunc(reduced[r:], func(row []*big.Rat) bool { return row[k].Sign() != 0 })
+		if pivot == -1 {
+			continue
+		}
+
+		reduced[r], reduced[r+pivot] = reduced[r+pivot], reduced[r]
+
+		value := new(big.Rat).Set(reduced[r][k])
+		for j := k; j < cols; j++ {
+			reduced[r][j].Quo(reduced[r][j], value)
+		}
+
+		for i := range rows {
+			if i == r || reduced[i][k].Sign() == 0 {
+				continue
+			}
+
+			factor := new(big.Rat).Set(reduced[i][k])
+			for j := k; j < cols; j++ {
+				reduced[i][j].Sub(reduced[i][j], term.Mul(factor, reduced[r][j]))
+			}
+		}
+
+		pivots = append(pivots, k)
+		r++
+	}
+
+	return reduced, pivots
+}
+
+func rankRats(vectors [][]*big.Rat) int {
+	if len(vectors) == 0 {
+		return 0
+	}
+	_, pivots := rrefRats(vectors)
+	return len(pivots)
+}
+
+// returns basis of the null space of the matrix as vectors
+func kernelRats(matrix [][]*big.Rat) [][]*big.Rat {
+	reduced, pivots := rrefRats(matrix)
+	cols := len(matrix[0])
+
+	var basis [][]*big.Rat
+	for free := range cols {
+		if slices.Contains(pivots, free) {
+			continue
+		}
+
+		vector := newRats(1, cols)[0]
+		vector[free].SetInt64(1)
+		for i, k := range pivots {
+			vector[k].Neg(reduced[i][free])
+		}
+		basis = append(basis, vector)
+	}
+
+	return basis
+}
+
+// returns coefficients of the characteristic polynomial det(xI - A),
+// index is the power. uses Faddeev-LeVerrier algorithm
+func characteristic(a [][]*big.Rat) []*big.Rat {
+	n := len(a)
+	coefficients := make([]*big.Rat, n+1)
+	coefficients[n] = big.NewRat(1, 1)
+
+	m := newRats(n, n)
+	for k := 1; k <= n; k++ {
+		// M_k = A M_(k-1) + c_(n-k+1) I
+		m = multiplyRats(a, m)
+		for i := range n {
+			m[i][i].Add(m[i][i], coefficients[n-k+1])
+		}
+
+		// c_(n-k) = -tr(A M_k) / k
+		am := multiplyRats(a, m)
+		trace := new(big.Rat)
+		for i := range n {
+			trace.Add(trace, am[i][i])
+		}
+		coefficients[n-k] = trace.Quo(trace, big.NewRat(int64(-k), 1))
+	}
+
+	return coefficients
+}
+
+func hornerRats(coefficients []*big.Rat, x *big.Rat) *big.Rat {
+	value := new(big.Rat)
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		value.Mul(value, x)
+		value.Add(value, coefficients[i])
+	}
+	return value
+}
+
+// divides polynomial by (x - root), root should be a root
+func deflateRats(coefficients []*big.Rat, root *big.Rat) []*big.Rat {
+	quotient := make([]*big.Rat, len(coefficients)-1)
+	carry := new(big.Rat)
+	for i := len(coefficients) - 1; i >= 1; i-- {
+		carry = new(big.Rat).Add(coefficients[i], new(big.Rat).Mul(carry, root))
+		quotient[i-1] = carry
+	}
+	return quotient
+}
+
+// removes zero coefficients of the highest powers,
+// zero polynomial has no coefficients
+func trimRats(coefficients []*big.Rat) []*big.Rat {
+	for len(coefficients) > 0 && coefficients[len(coefficients)-1].Sign() == 0 {
+		coefficients = coefficients[:len(coefficients)-1]
+	}
+	return coefficients
+}
+
+// divides polynomial a by nonzero polynomial b with remainder
+func divideRats(a, b []*big.Rat) ([]*big.Rat, []*big.Rat) {
+	remainder := make([]*big.Rat, len(a))
+	for i, c := range a {
+		remainder[i] = new(big.Rat).Set(c)
+	}
+	if len(a) < len(b) {
+		return nil, trimRats(remainder)
+	}
+
+	quotient := make([]*big.Rat, len(a)-len(b)+1)
+	term := new(big.Rat)
+	for i := len(quotient) - 1; i >= 0; i-- {
+		factor := new(big.Rat).Quo(remainder[i+len(b)-1], b[len(b)-1])
+		for j, c := range b {
+			remainder[i+j].Sub(remainder[i+j], term.Mul(factor, c))
+		}
+		quotient[i] = factor
+	}
+
+	return quotient, trimRats(remainder[:len(b)-1])
+}
+
+// returns the polynomial with the same roots, but all of them are simple.
+// it is p / gcd(p, p')
+func squareFreeRats(coefficients []*big.Rat) []*big.Rat {
+	derivative := make([]*big.Rat, len(coefficients)-1)
+	for i := range derivative {
+		derivative[i] = new(big.Rat).Mul(coefficients[i+1], big.NewRat(int64(i+1), 1))
+	}
+
+	a, b := coefficients, trimRats(derivative)
+	for len(b) > 0 {
+		_, remainder := divideRats(a, b)
+		a, b = b, remainder
+	}
+
+	quotient, _ := divideRats(coefficients, a)
+	return quotient
+}
+
+type bigComplex struct {
+	re, im *big.Float
+}
+
+func (z bigComplex) sub(w bigComplex) bigComplex {
+	return bigComplex{new(big.Float).Sub(z.re, w.re), new(big.Float).Sub(z.im, w.im)}
+}
+
+func (z bigComplex) mul(w bigComplex) bigComplex {
+	re := new(big.Float).Mul(z.re, w.re)
+	re.Sub(re, new(big.Float).Mul(z.im, w.im))
+	im := new(big.Float).Mul(z.re, w.im)
+	im.Add(im, new(big.Float).Mul(z.im, w.re))
+	return bigComplex{re, im}
+}
+
+func (z bigComplex) quo(w bigComplex) bigComplex {
+	norm := new(big.Float).Mul(w.re, w.re)
+	norm.Add(norm, new(big.Float).Mul(w.im, w.im))
+
+	conjugate := bigComplex{w.re, new(big.Float).Neg(w.im)}
+	product := z.mul(conjugate)
+	return bigComplex{product.re.Quo(product.re, norm), product.im.Quo(product.im, norm)}
+}
+
+// returns binary exponent of the larger part, zero has the smallest one
+func (z bigComplex) exponent() int {
+	exponent := math.MinInt
+	for _, part := range []*big.Float{z.re, z.im} {
+		if part.Sign() != 0 {
+			exponent = max(exponent, part.MantExp(nil))
+		}
+	}
+	return exponent
+}
+
+// returns approximate roots of the polynomial with simple roots
+// using Durand-Kerner method with the precision in bits
+func approximateRoots(coefficients []*big.Rat, precision uint) []bigComplex {
+	n := len(coefficients) - 1
+
+	monic := make([]bigComplex, n+1)
+	for i, c := range coefficients {
+		value := new(big.Rat).Quo(c, coefficients[n])
+		monic[i] = bigComplex{new(big.Float).SetPrec(precision).SetRat(value), new(big.Float).SetPrec(precision)}
+	}
+
+	value := func(z bigComplex) bigComplex {
+		result := bigComplex{new(big.Float).SetPrec(precision), new(big.Float).SetPrec(precision)}
+		for i := n; i >= 0; i-- {
+			result = result.mul(z)
+			result.re.Add(result.re, monic[i].re)
+			result.im.Add(result.im, monic[i].im)
+		}
+		return result
+	}
+
+	// roots start on the circle with all roots inside
+	radius := big.NewFloat(0).SetPrec(precision)
+	for _, c := range monic[:n] {
+		if abs := new(big.Float).Abs(c.re); abs.Cmp(radius) > 0 {
+			radius = abs
+		}
+	}
+	radius.Add(radius, big.NewFloat(1))
+
+	roots := make([]bigComplex, n)
+	for k := range roots {
+		angle := 2*math.Pi*float64(k)/float64(n) + 0.4
+		roots[k] = bigComplex{
+			new(big.Float).Mul(radius, big.NewFloat(math.Cos(angle))),
+			new(big.Float).Mul(radius, big.NewFloat(math.Sin(angle))),
+		}
+	}
+
+	for range 1000 {
+		converged := true
+		for k := range n {
+			denominator := bigComplex{new(big.Float).SetPrec(precision).SetInt64(1), new(big.Float).SetPrec(precision)}
+			for j := range n {
+				if j != k {
+					denominator = denominator.mul(roots[k].sub(roots[j]))
+				}
+			}
+
+			step := value(roots[k]).quo(denominator)
+			roots[k] = roots[k].sub(step)
+
+			if step.exponent() > max(roots[k].exponent(), 0)-int(precision)+16 {
+				converged = false
+			}
+		}
+
+		if converged {
+			break
+		}
+	}
+
+	return roots
+}
+
+// returns rational roots of the polynomial in ascending order
+// with their multiplicities, all roots should be rational
+func rationalEigenvalues(coefficients []*big.Rat) ([]*big.Rat, []int, error) {
+	var values []*big.Rat
+	var multiplicities []int
+
+	if zeros := slices.IndexFunc(coefficients, func(c *big.Rat) bool { return c.Sign() != 0 }); zeros > 0 {
+		values = append(values, new(big.Rat))
+		multiplicities = append(multiplicities, zeros)
+		coefficients = coefficients[zeros:]
+	}
+
+	if len(coefficients) > 1 {
+		// the polynomial with integer coefficients has the same roots,
+		// denominators of the rational roots divide its leading coefficient
+		lcm := big.NewInt(1)
+		for _, c := range coefficients {
+			gcd := new(big.Int).GCD(nil, nil, lcm, c.Denom())
+			lcm.Mul(lcm, new(big.Int).Quo(c.Denom(), gcd))
+		}
+		leading := new(big.Int).Quo(lcm, coefficients[len(coefficients)-1].Denom())
+		leading.Mul(leading, coefficients[len(coefficients)-1].Num())
+
+		bits := 0
+		for _, c := range coefficients {
+			scaled := new(big.Int).Mul(c.Num(), new(big.Int).Quo(lcm, c.Denom()))
+			bits = max(bits, scaled.BitLen())
+		}
+		precision := uint(64 + 2*len(coefficients)*bits)
+
+		// approximate roots rounded to the nearest fraction
+		// with this denominator are checked exactly
+		for _, root := range approximateRoots(squareFreeRats(coefficients), precision) {
+			scaled := new(big.Float).Mul(root.re, new(big.Float).SetInt(leading))
+			if scaled.Sign() < 0 {
+				scaled.Sub(scaled, big.NewFloat(0.5))
+			} else {
+				scaled.Add(scaled, big.NewFloat(0.5))
+			}
+			numerator, _ := scaled.Int(nil)
+
+			candidate := new(big.Rat).SetFrac(numerator, leading)
+			if slices.ContainsFunc(values, func(v *big.Rat) bool { return v.Cmp(candidate) == 0 }) {
+				continue
+			}
+
+			multiplicity := 0
+			for len(coefficients) > 1 && hornerRats(coefficients, candidate).Sign() == 0 {
+				coefficients = deflateRats(coefficients, candidate)
+				multiplicity++
+			}
+			if multiplicity > 0 {
+				values = append(values, candidate)
+				multiplicities = append(multiplicities, multiplicity)
+			}
+		}
+	}
+
+	if len(coefficients) > 1 {
+		return nil, nil, errors.New("eigenvalues are not rational")
+	}
+
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortFunc(order, func(i, j int) int { return values[i].Cmp(values[j]) })
+
+	sortedValues := make([]*big.Rat, len(values))
+	sortedMultiplicities := make([]int, len(values))
+	for i, k := range order {
+		sortedValues[i], sortedMultiplicities[i] = values[k], multiplicities[k]
+	}
+
+	return sortedValues, sortedMultiplicities, nil
+}
+
+// returns jordan chains of the eigenvalue, every chain starts with
+// an eigenvector and is followed by its generalized eigenvectors
+func jordanChains(a [][]*big.Rat, value *big.Rat, multiplicity int) [][][]*big.Rat {
+	n := len(a)
+	shifted := newRats(n, n)
+	for i := range n {
+		for j := range n {
+			shifted[i][j].Set(a[i][j])
+		}
+		shifted[i][i].Sub(shifted[i][i], value)
+	}
+
+	// kernels of the powers of A - value*I grow up to the multiplicity
+	kernels := [][][]*big.Rat{nil}
+	power := shifted
+	for len(kernels[len(kernels)-1]) < multiplicity {
+		kernels = append(kernels, kernelRats(power))
+		power = multiplyRats(power, shifted)
+	}
+
+	apply := func(vector []*big.Rat) []*big.Rat {
+		column := make([][]*big.Rat, n)
+		for i := range vector {
+			column[i] = []*big.Rat{vector[i]}
+		}
+
+		product := multiplyRats(shifted, column)
+		result := make([]*big.Rat, n)
+		for i := range product {
+			result[i] = product[i][0]
+		}
+		return result
+	}
+
+	// chains are stored from the generalized eigenvector of the highest level
+	var chains [][][]*big.Rat
+	for level := len(kernels) - 1; level >= 1; level-- {
+		// vectors of the longer chains at this level, they are
+		// independent with the kernel of the previous level
+		base := slices.Clone(kernels[level-1])
+		for i, chain := range chains {
+			next := apply(chain[len(chain)-1])
+			chains[i] = append(chain, next)
+			base = append(base, next)
+		}
+
+		rank := rankRats(base)
+		for _, vector := range kernels[level] {
+			if rankRats(append(base, vector)) > rank {
+				base = append(base, vector)
+				rank++
+				chains = append(chains, [][]*big.Rat{vector})
+			}
+		}
+	}
+
+	for _, chain := range chains {
+		slices.Reverse(chain)
+	}
+
+	return chains
+}
+
+// returns jordan normal form of the matrix with the transition matrix.
+// all eigenvalues should be rational
+func (m *Matrix) Jordan() (*JordanForm, error) {
+	if m.Augmented || m.Rows != m.Cols {
+		return nil, errors.New("matrix is not a square")
+	}
+
+	a, err := toRats(m.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	values, multiplicities, err := rationalEigenvalues(characteristic(a))
+	if err != nil {
+		return nil, err
+	}
+
+	n := m.Rows
+	form := &JordanForm{J: newRats(n, n), P: newRats(n, n)}
+
+	col := 0
+	for i, value := range values {
+		for _, chain := range jordanChains(a, value, multiplicities[i]) {
+			form.Blocks = append(form.Blocks, JordanBlock{value, len(chain)})
+
+			for k, vector := range chain {
+				for row := range n {
+					form.P[row][col+k].Set(vector[row])
+				}
+
+				form.J[col+k][col+k].Set(value)
+				if k > 0 {
+					form.J[col+k-1][col+k].SetInt64(1)
+				}
+			}
+			col += len(chain)
+		}
+	}
+
+	return form, nil
+}
+
+func formatRats(matrix [][]*big.Rat) string {
+	rows := make([]string, len(matrix))
+	for i, row := range matrix {
+		values := make([]string, len(row))
+		for j, value := range row {
+			values[j] = value.RatString()
+		}
+		rows[i] = strings.Join(values, " ")
+	}
+	return "[" + strings.Join(rows, "; ") + "]"
+}
+
+func (f *JordanForm) String() string {
+	blocks := make([]string, len(f.Blocks))
+	for i, block := range f.Blocks {
+		blocks[i] = fmt.Sprintf("J%d(%s)", block.Size, block.Value.RatString())
+	}
+
+	return fmt.Sprintf("J = %s, P = %s", strings.Join(blocks, " ⊕ "), formatRats(f.P))
+}
@@ -0,0 +1,66 @@
+package matrix
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestJordan(t *testing.T) {
+	tests := []struct {
+		name    string
+		matrix  [][]float64
+		want    string
+		wantErr bool
+	}{
+		{"diagonal", [][]float64{{3, 0}, {0, 2}}, "J1(2) ⊕ J1(3)", false},
+		{"block", [][]float64{{2, 1}, {0, 2}}, "J2(2)", false},
+		{"fraction", [][]float64{{0.5, 1}, {0, 0.5}}, "J2(1/2)", false},
+		{"zero", [][]float64{{0, 0}, {0, 0}}, "J1(0) ⊕ J1(0)", false},
+		{"nilpotent", [][]float64{{0, 1, 0}, {0, 0, 1}, {0, 0, 0}}, "J3(0)", false},
+		{"mixed", [][]float64{
+			{5, 4, 2, 1},
+			{0, 1, -1, -1},
+			{-1, -1, 3, 0},
+			{1, 1, -1, 2},
+		}, "J1(1) ⊕ J1(2) ⊕ J2(4)", false},
+		{"two blocks", [][]float64{
+			{2, 1, 0, 0},
+			{0, 2, 0, 0},
+			{0, 0, 2, 0},
+			{1, 0, 0, 2},
+		}, "J3(2) ⊕ J1(2)", false},
+		{"irrational", [][]float64{{0, 1}, {1, 1}}, "", true},
+		{"large", [][]float64{{1 << 30, 1}, {1, 1 << 30}}, "J1(1073741823) ⊕ J1(1073741825)", false},
+		{"large fraction", [][]float64{{0.5, 1}, {0, 1 << 42}}, "J1(1/2) ⊕ J1(4398046511104)", false},
+		{"large block", [][]float64{{1 << 40, 1}, {0, 1 << 40}}, "J2(1099511627776)", false},
+		{"complex", [][]float64{{0, -1}, {1, 0}}, "", true},
+		{"not square", matrix5, "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				matrix, _ := NewMatrix(test.matrix, false)
+				form, err := matrix.Jordan()
+				if (err != nil) != test.wantErr {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if err != nil {
+					return
+				}
+
+				if ans := form.String(); !strings.HasPrefix(ans, "J = "+test.want+", ") {
+					t.Errorf("got %q, want blocks %q", ans, test.want)
+				}
+
+				// A P = P J, and P is invertible
+				a, _ := toRats(test.matrix)
+				if left, right := multiplyRats(a, form.P), multiplyRats(form.P, form.J); formatRats(left) != formatRats(right) {
+					t.Errorf("A P = %s, P J = %s", formatRats(left), formatRats(right))
+				}
+				if rank := rankRats(form.P); rank != matrix.Rows {
+					t.Errorf("transition matrix has rank %d", rank)
+				}
+			})
+	}
+}
@@ -9,7 +9,7 @@ import (
 )
 
 type Number interface {
-	int | float64
+	int | int64 | float64 | complex128
 }
 
 type Matrix struct {
@@ -20,6 +20,14 @@ type Matrix struct {
 	Square       bool
 	Determinants []float64
 	Roots        []float64
+
+	// copy of the data the determinants were calculated for,
+	// values can be edited in place after that
+	calculated [][]float64
+
+	// metadata from the header of the text file
+	Name      string
+	Precision int // digits after the point when written, 0 is the shortest form
 }
 
 func NewBlankMatrix(rows, cols int, augmented bool) (*Matrix, error) {
@@ -32,8 +40,8 @@ func NewBlankMatrix(rows, cols int, augmented bool) (*Matrix, error) {
 
 	m.Augmented = augmented
 	m.Square = isSquare(m.Augmented, m.Rows, m.Cols)
-	m.Determinants = makeDets(m.Augmented, m.Cols)
-	m.Roots = makeRoots(m.Augmented, m.Cols)
+	m.Determinants = makeDets[float64](m.Augmented, m.Cols)
+	m.Roots = makeRoots[float64](m.Augmented, m.Cols)
 
 	return m, nil
 }
@@ -48,8 +56,8 @@ func NewMatrix(data [][]float64, augmented bool) (*Matrix, error) {
 
 	m.Augmented = augmented
 	m.Square = isSquare(m.Augmented, m.Rows, m.Cols)
-	m.Determinants = makeDets(m.Augmented, m.Cols)
-	m.Roots = makeRoots(m.Augmented, m.Cols)
+	m.Determinants = makeDets[float64](m.Augmented, m.Cols)
+	m.Roots = makeRoots[float64](m.Augmented, m.Cols)
 
 	return m, nil
 }
@@ -76,17 +84,17 @@ func isSquare(augmented bool, rows, cols int) bool {
 	}
 }
 
-func makeDets(augmented bool, cols int) []float64 {
+func makeDets[number Number](augmented bool, cols int) []number {
 	if augmented {
-		return make([]float64, cols)
+		return make([]number, cols)
 	} else {
-		return make([]float64, 1)
+		return make([]number, 1)
 	}
 }
 
-func makeRoots(augmented bool, cols int) []float64 {
+func makeRoots[number Number](augmented bool, cols int) []number {
 	if augmented {
-		return make([]float64, cols-1)
+		return make([]number, cols-1)
 	} else {
 		return nil
 	}
@@ -130,10 +138,28 @@ func (m *Matrix) Calculate() ([]float64, error) {
 			}
 		}
 	}
+	m.calculated = cloneData(m.Data)
 
 	return m.Determinants, nil
 }
 
+// reports whether the determinants were calculated for the current data
+func (m *Matrix) isCalculated() bool {
+	return m.calculated != nil && slices.EqualFunc(m.Data, m.calculated, slices.Equal[[]float64])
+}
+
+func cloneData(data [][]float64) [][]float64 {
+	if data == nil {
+		return nil
+	}
+
+	clone := make([][]float64, len(data))
+	for i, row := range data {
+		clone[i] = slices.Clone(row)
+	}
+	return clone
+}
+
 func (m *Matrix) GetRoots() []float64 {
 	if m.Determinants == nil {
 		return nil
@@ -247,6 +273,22 @@ func deleteCol[number Number](matrix [][]number, col int) (newMatrix [][]number)
 	return
 }
 
+func deleteRow[number Number](matrix [][]number, row int) (newMatrix [][]number) {
+	rows, cols := len(matrix)-1, len(matrix[0])
+
+	newMatrix, memory, _ := Malloc[number](rows, cols)
+	for i := range newMatrix {
+		newMatrix[i] = memory[(i * cols):((i + 1) * cols)]
+		if i < row {
+			copy(newMatrix[i], matrix[i])
+		} else {
+			copy(newMatrix[i], matrix[i+1])
+		}
+	}
+
+	return
+}
+
 func replaceColInAugmented[number Number](matrix [][]number, index int) (newMatrix [][]number) {
 	rows, cols := len(matrix), len(matrix[0])-1
 
@@ -264,35 +306,52 @@ func (m *Matrix) GetAdjugate() (newMatrix [][]float64) {
 		return nil
 	}
 
-	adjugate, memory, _ := Malloc[float64](m.Rows, m.Cols)
-	for i := range m.Rows {
-		adjugate[i] = memory[(i * m.Cols) : (i+1)*m.Cols]
-		for j := range m.Cols {
-			// sign := m.Data[i][j]
-			sign := 1.0
-			if (i+j)%2 != 0 {
-				sign = -sign
-			}
-			matrix := deleteRowAndCol(m.Data, i, j)
-			adjugate[i][j] = calcDet(matrix) * sign
+	return adjugate(m.Data)
+}
+
+func adjugate[number Number](matrix [][]number) [][]number {
+	rows, cols := len(matrix), len(matrix[0])
+
+	adjugate, memory, _ := Malloc[number](rows, cols)
+	for i := range rows {
+		adjugate[i] = memory[(i * cols) : (i+1)*cols]
+		for j := range cols {
+			adjugate[i][j] = cofactor(matrix, i, j)
 		}
 	}
 
 	return adjugate
 }
 
+func cofactor[number Number](matrix [][]number, row, col int) number {
+	// minors of 1x1 matrix are empty, cofactor is defined as 1
+	if len(matrix) == 1 {
+		return 1
+	}
+
+	minor := calcDet(deleteRowAndCol(matrix, row, col))
+	if (row+col)%2 != 0 {
+		minor = -minor
+	}
+
+	return minor
+}
+
 func (m *Matrix) GetTranspose() (newMatrix [][]float64) {
-	transpose, memory, _ := Malloc[float64](m.Cols, m.Rows)
+	return transpose(m.Data)
+}
 
-	for i := range m.Cols {
-		for _ = range m.Rows {
-			transpose[i] = memory[(i * m.Rows) : (i+1)*m.Rows]
-		}
+func transpose[number Number](matrix [][]number) [][]number {
+	rows, cols := len(matrix), len(matrix[0])
+
+	transpose, memory, _ := Malloc[number](cols, rows)
+	for i := range cols {
+		transpose[i] = memory[(i * rows) : (i+1)*rows]
 	}
 
-	for i := range m.Rows {
-		for j := range m.Cols {
-			transpose[j][i] = m.Data[i][j]
+	for i := range rows {
+		for j := range cols {
+			transpose[j][i] = matrix[i][j]
 		}
 	}
 
@@ -312,12 +371,16 @@ func (m *Matrix) GetInverse() (newMatrix [][]float64) {
 		return nil
 	}
 
-	adjugate, _ := NewMatrix(m.GetAdjugate(), m.Augmented)
-	inverse := adjugate.GetTranspose()
+	return inverse(m.Data, m.Determinants[0])
+}
+
+// inverse is the transposed adjugate divided by the determinant
+func inverse[number Number](matrix [][]number, det number) [][]number {
+	inverse := transpose(adjugate(matrix))
 
 	for i := range len(inverse) {
 		for j := range len(inverse[0]) {
-			inverse[i][j] /= m.Determinants[0]
+			inverse[i][j] /= det
 		}
 	}
 
@@ -329,14 +392,19 @@ func (m *Matrix) Multiply(matrix *Matrix) (newMatrix [][]float64) {
 		return nil
 	}
 
-	rows, cols := m.Rows, matrix.Cols
-	newMatrix, memory, _ := Malloc[float64](rows, cols)
+	return multiply(m.Data, matrix.Data)
+}
+
+func multiply[number Number](a, b [][]number) [][]number {
+	rows, cols, common := len(a), len(b[0]), len(b)
+
+	newMatrix, memory, _ := Malloc[number](rows, cols)
 	for i := range rows {
 		newMatrix[i] = memory[i*cols : (i+1)*cols]
 		for j := range cols {
-			cellValue := 0.0
-			for k := range m.Cols {
-				cellValue += m.Data[i][k] * matrix.Data[k][j]
+			cellValue := number(0)
+			for k := range common {
+				cellValue += a[i][k] * b[k][j]
 			}
 			newMatrix[i][j] = cellValue
 		}
@@ -362,6 +430,7 @@ func (m *Matrix) AddRow(index int) {
 	}
 
 	m.Data = matrix
+	m.refresh()
 }
 
 func (m *Matrix) AddCol(index int) {
@@ -383,6 +452,146 @@ func (m *Matrix) AddCol(index int) {
 	}
 
 	m.Data = matrix
+	m.refresh()
+}
+
+func (m *Matrix) checkRow(index int) error {
+	if index < 0 || index >= m.Rows {
+		return fmt.Errorf("row index %d is out of range [0, %d)", index, m.Rows)
+	}
+
+	return nil
+}
+
+func (m *Matrix) checkCol(index int) error {
+	if index < 0 || index >= m.Cols {
+		return fmt.Errorf("column index %d is out of range [0, %d)", index, m.Cols)
+	}
+
+	return nil
+}
+
+// recalculates fields depending on the dimensions after rows or columns were added or deleted
+func (m *Matrix) refresh() {
+	m.Square = isSquare(m.Augmented, m.Rows, m.Cols)
+	m.Determinants = makeDets[float64](m.Augmented, m.Cols)
+	m.Roots = makeRoots[float64](m.Augmented, m.Cols)
+	m.calculated = nil
+}
+
+func (m *Matrix) DeleteRow(index int) error {
+	if err := m.checkRow(index); err != nil {
+		return err
+	}
+
+	if m.Rows == 1 {
+		return errors.New("cannot delete the only row")
+	}
+
+	m.Data = deleteRow(m.Data, index)
+	m.Rows--
+	m.refresh()
+
+	return nil
+}
+
+func (m *Matrix) DeleteCol(index int) error {
+	if err := m.checkCol(index); err != nil {
+		return err
+	}
+
+	if m.Cols == 1 {
+		return errors.New("cannot delete the only column")
+	}
+
+	m.Data = deleteCol(m.Data, index)
+	m.Cols--
+	m.refresh()
+
+	return nil
+}
+
+func (m *Matrix) SwapRows(i, j int) error {
+	if err := m.checkRow(i); err != nil {
+		return err
+	}
+
+	if err := m.checkRow(j); err != nil {
+		return err
+	}
+
+	// rows are views of one contingious slice, so values are swapped, not slices
+	for k := range m.Cols {
+		m.Data[i][k], m.Data[j][k] = m.Data[j][k], m.Data[i][k]
+	}
+
+	return nil
+}
+
+func (m *Matrix) SwapCols(i, j int) error {
+	if err := m.checkCol(i); err != nil {
+		return err
+	}
+
+	if err := m.checkCol(j); err != nil {
+		return err
+	}
+
+	for k := range m.Rows {
+		m.Data[k][i], m.Data[k][j] = m.Data[k][j], m.Data[k][i]
+	}
+
+	return nil
+}
+
+// multiplies row by non-zero scalar
+func (m *Matrix) ScaleRow(index int, scalar float64) error {
+	if err := m.checkRow(index); err != nil {
+		return err
+	}
+
+	if scalar == 0 {
+		return errors.New("scalar is zero")
+	}
+
+	for k := range m.Cols {
+		m.Data[index][k] *= scalar
+	}
+
+	return nil
+}
+
+// adds row src multiplied by scalar to row dst
+func (m *Matrix) AddRowMultiple(dst, src int, scalar float64) error {
+	if err := m.checkRow(dst); err != nil {
+		return err
+	}
+
+	if err := m.checkRow(src); err != nil {
+		return err
+	}
+
+	if dst == src {
+		return errors.New("row cannot be added to itself")
+	}
+
+	for k := range m.Cols {
+		m.Data[dst][k] += m.Data[src][k] * scalar
+	}
+
+	return nil
+}
+
+func (m *Matrix) Clone() *Matrix {
+	matrix := &Matrix{}
+	matrix.Write(m.Data)
+	matrix.Augmented = m.Augmented
+	matrix.Square = m.Square
+	matrix.Determinants = slices.Clone(m.Determinants)
+	matrix.Roots = slices.Clone(m.Roots)
+	matrix.calculated = cloneData(m.calculated)
+
+	return matrix
 }
 
 func (m *Matrix) ExtendRows(rows int) {
@@ -476,6 +685,7 @@ func (m *Matrix) Write(data [][]float64) error {
 	m.Data = matrix
 	m.Rows = rows
 	m.Cols = cols
+	m.calculated = nil
 
 	return nil
 }
@@ -493,6 +703,7 @@ func (m *Matrix) WriteBlank(rows, cols int) error {
 	m.Data = matrix
 	m.Rows = rows
 	m.Cols = cols
+	m.calculated = nil
 
 	return nil
 }
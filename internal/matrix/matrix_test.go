@@ -340,4 +340,169 @@ func TestResize(t *testing.T) {
 				}
 			})
 	}
-}
\ No newline at end of file
+}
+
+func TestDeleteRow(t *testing.T) {
+	tests := []struct {
+		name    string
+		matrix  [][]float64
+		arg     int
+		want    string
+		wantErr bool
+	}{
+		{ "arg -1", matrix6, -1, MatrixToString(matrix6, " "), true },
+		{ "arg 0 with row", matrix5, 0, "1 2 3\n", true },
+		{ "arg 0 with three rows", matrix6, 0, "4 5 6\n7 8 9\n", false },
+		{ "arg 1 with three rows", matrix6, 1, "1 2 3\n7 8 9\n", false },
+		{ "arg 2 with column", matrix8, 2, "1\n2\n", false },
+		{ "arg 3 with three rows", matrix6, 3, MatrixToString(matrix6, " "), true },
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				matrix, _ := NewMatrix(test.matrix, false)
+				err := matrix.DeleteRow(test.arg)
+				if (err != nil) != test.wantErr {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				ans := MatrixToString(matrix.Data, " ")
+				if ans != test.want {
+					t.Errorf("\ngot:\n%s\nwant:\n%s", ans, test.want)
+				}
+			})
+	}
+}
+
+func TestDeleteCol(t *testing.T) {
+	tests := []struct {
+		name    string
+		matrix  [][]float64
+		arg     int
+		want    string
+		wantErr bool
+	}{
+		{ "arg -1", matrix6, -1, MatrixToString(matrix6, " "), true },
+		{ "arg 0 with column", matrix8, 0, "1\n2\n3\n", true },
+		{ "arg 0 with three rows", matrix6, 0, "2 3\n5 6\n8 9\n", false },
+		{ "arg 1 with row", matrix5, 1, "1 3\n", false },
+		{ "arg 2 with three rows", matrix6, 2, "1 2\n4 5\n7 8\n", false },
+		{ "arg 3 with three rows", matrix6, 3, MatrixToString(matrix6, " "), true },
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				matrix, _ := NewMatrix(test.matrix, false)
+				err := matrix.DeleteCol(test.arg)
+				if (err != nil) != test.wantErr {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				ans := MatrixToString(matrix.Data, " ")
+				if ans != test.want {
+					t.Errorf("\ngot:\n%s\nwant:\n%s", ans, test.want)
+				}
+			})
+	}
+}
+
+func TestSwap(t *testing.T) {
+	tests := []struct {
+		name    string
+		matrix  [][]float64
+		rows    bool
+		arg1    int
+		arg2    int
+		want    string
+		wantErr bool
+	}{
+		{ "rows 0, 2", matrix6, true, 0, 2, "7 8 9\n4 5 6\n1 2 3\n", false },
+		{ "rows 1, 1", matrix6, true, 1, 1, "1 2 3\n4 5 6\n7 8 9\n", false },
+		{ "rows 0, 3", matrix6, true, 0, 3, "1 2 3\n4 5 6\n7 8 9\n", true },
+		{ "cols 0, 1", matrix6, false, 0, 1, "2 1 3\n5 4 6\n8 7 9\n", false },
+		{ "cols -1, 1", matrix5, false, -1, 1, "1 2 3\n", true },
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				matrix, _ := NewMatrix(test.matrix, false)
+				var err error
+				if test.rows {
+					err = matrix.SwapRows(test.arg1, test.arg2)
+				} else {
+					err = matrix.SwapCols(test.arg1, test.arg2)
+				}
+				if (err != nil) != test.wantErr {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				ans := MatrixToString(matrix.Data, " ")
+				if ans != test.want {
+					t.Errorf("\ngot:\n%s\nwant:\n%s", ans, test.want)
+				}
+			})
+	}
+}
+
+func TestScaleRow(t *testing.T) {
+	tests := []struct {
+		name    string
+		matrix  [][]float64
+		index   int
+		scalar  float64
+		want    string
+		wantErr bool
+	}{
+		{ "row 1 by 2", matrix6, 1, 2, "1 2 3\n8 10 12\n7 8 9\n", false },
+		{ "row 0 by -1", matrix5, 0, -1, "-1 -2 -3\n", false },
+		{ "row 0 by 0", matrix5, 0, 0, "1 2 3\n", true },
+		{ "row 1 with row", matrix5, 1, 2, "1 2 3\n", true },
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				matrix, _ := NewMatrix(test.matrix, false)
+				err := matrix.ScaleRow(test.index, test.scalar)
+				if (err != nil) != test.wantErr {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				ans := MatrixToString(matrix.Data, " ")
+				if ans != test.want {
+					t.Errorf("\ngot:\n%s\nwant:\n%s", ans, test.want)
+				}
+			})
+	}
+}
+
+func TestAddRowMultiple(t *testing.T) {
+	tests := []struct {
+		name    string
+		matrix  [][]float64
+		dst     int
+		src     int
+		scalar  float64
+		want    string
+		wantErr bool
+	}{
+		{ "row 1 -= 4 row 0", matrix6, 1, 0, -4, "1 2 3\n0 -3 -6\n7 8 9\n", false },
+		{ "row 0 += row 2", matrix6, 0, 2, 1, "8 10 12\n4 5 6\n7 8 9\n", false },
+		{ "row 0 to itself", matrix6, 0, 0, 1, "1 2 3\n4 5 6\n7 8 9\n", true },
+		{ "row 3", matrix6, 3, 0, 1, "1 2 3\n4 5 6\n7 8 9\n", true },
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				matrix, _ := NewMatrix(test.matrix, false)
+				err := matrix.AddRowMultiple(test.dst, test.src, test.scalar)
+				if (err != nil) != test.wantErr {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				ans := MatrixToString(matrix.Data, " ")
+				if ans != test.want {
+					t.Errorf("\ngot:\n%s\nwant:\n%s", ans, test.want)
+				}
+			})
+	}
+}
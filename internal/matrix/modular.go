@@ -0,0 +1,317 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"strings"
+)
+
+// matrix over integers modulo Modulus. values are kept in [0, Modulus).
+//
+// modulus does not have to be prime: elimination uses only
+// unimodular row operations (euclidean algorithm on the rows),
+// so the determinant is exact for any modulus, and the inverse exists
+// when the determinant is coprime with the modulus
+type ModularMatrix struct {
+	Data      [][]int64
+	Rows      int
+	Cols      int
+	Modulus   int64
+	Augmented bool
+	Square    bool
+}
+
+// converts real values to residues. values should be integers
+func NewModularMatrix(data [][]float64, modulus int64, augmented bool) (*ModularMatrix, error) {
+	if modulus < 2 {
+		return nil, fmt.Errorf("modulus %d should be at least 2", modulus)
+	}
+
+	if data == nil || data[0] == nil {
+		return nil, errors.New("error: data is nil")
+	}
+
+	rows, cols := len(data), len(data[0])
+	if rows == 0 || cols == 0 {
+		return nil, errors.New("error: data is empty")
+	}
+
+	matrix, memory, _ := Malloc[int64](rows, cols)
+	for i := range rows {
+		if len(data[i]) != cols {
+			return nil, errors.New("error: data is not rectangle-shaped")
+		}
+
+		matrix[i] = memory[i*cols : (i+1)*cols]
+		for j, value := range data[i] {
+			if value != math.Trunc(value) {
+				return nil, fmt.Errorf("value %v at (%d, %d) is not an integer", value, i+1, j+1)
+			}
+			// float64 bounds of int64 are exact powers of two
+			if value < math.MinInt64 || value >= -math.MinInt64 {
+				return nil, fmt.Errorf("value %v at (%d, %d) is out of the int64 range", value, i+1, j+1)
+			}
+			matrix[i][j] = reduce(int64(value), modulus)
+		}
+	}
+
+	return &ModularMatrix{
+		Data:      matrix,
+		Rows:      rows,
+		Cols:      cols,
+		Modulus:   modulus,
+		Augmented: augmented,
+		Square:    isSquare(augmented, rows, cols),
+	}, nil
+}
+
+func reduce(value, modulus int64) int64 {
+	value %= modulus
+	if value < 0 {
+		value += modulus
+	}
+	return value
+}
+
+func mulMod(a, b, modulus int64) int64 {
+	hi, lo := bits.Mul64(uint64(a), uint64(b))
+	return int64(bits.Rem64(hi, lo, uint64(modulus)))
+}
+
+func addMod(a, b, modulus int64) int64 {
+	if a >= modulus-b {
+		return a - (modulus - b)
+	}
+	return a + b
+}
+
+func subMod(a, b, modulus int64) int64 {
+	if a >= b {
+		return a - b
+	}
+	return a - b + modulus
+}
+
+// returns x such that a*x = 1 (mod modulus)
+func ModInverse(a, modulus int64) (int64, error) {
+	a = reduce(a, modulus)
+
+	oldR, r := a, modulus
+	oldS, s := int64(1), int64(0)
+	for r != 0 {
+		q := oldR / r
+		oldR, r = r, oldR-q*r
+		oldS, s = s, oldS-q*s
+	}
+
+	if oldR != 1 {
+		return 0, fmt.Errorf("%d has no inverse modulo %d", a, modulus)
+	}
+
+	return reduce(oldS, modulus), nil
+}
+
+func (m *ModularMatrix) clone() [][]int64 {
+	matrix, memory, _ := Malloc[int64](m.Rows, m.Cols)
+	for i := range m.Rows {
+		matrix[i] = memory[i*m.Cols : (i+1)*m.Cols]
+		copy(matrix[i], m.Data[i])
+	}
+	return matrix
+}
+
+// reduces first cols columns of the matrix to the upper triangular form in place.
+// returns -1 if odd number of rows were swapped, otherwise 1
+func echelonMod(matrix [][]int64, cols int, modulus int64) int64 {
+	sign := int64(1)
+	rows := len(matrix)
+
+	for r, k := 0, 0; r < rows && k < cols; k++ {
+		for {
+			// row with the smallest non-zero value becomes the pivot
+			pivot := -1
+			for i := r; i < rows; i++ {
+				if matrix[i][k] != 0 && (pivot == -1 || matrix[i][k] < matrix[pivot][k]) {
+					pivot = i
+				}
+			}
+
+			if pivot == -1 {
+				break
+			}
+
+			if pivot != r {
+				matrix[pivot], matrix[r] = matrix[r], matrix[pivot]
+				sign = -sign
+			}
+
+			done := true
+			for i := r + 1; i < rows; i++ {
+				if matrix[i][k] == 0 {
+					continue
+				}
+
+				q := matrix[i][k] / matrix[r][k]
+				for j := k; j < len(matrix[i]); j++ {
+					matrix[i][j] = subMod(matrix[i][j], mulMod(q, matrix[r][j], modulus), modulus)
+				}
+
+				if matrix[i][k] != 0 {
+					done = false
+				}
+			}
+
+			if done {
+				r++
+				break
+			}
+		}
+	}
+
+	return sign
+}
+
+func (m *ModularMatrix) coefficients() [][]int64 {
+	if !m.Augmented {
+		return m.Data
+	}
+
+	return deleteCol(m.Data, m.Cols-1)
+}
+
+// returns determinant of the matrix (of the coefficients, if augmented)
+func (m *ModularMatrix) Determinant() (int64, error) {
+	if !m.Square {
+		return 0, errors.New("matrix is not a square")
+	}
+
+	coefficients := m.coefficients()
+	n := len(coefficients)
+
+	matrix := (&ModularMatrix{Data: coefficients, Rows: n, Cols: n}).clone()
+	sign := echelonMod(matrix, n, m.Modulus)
+
+	det := reduce(sign, m.Modulus)
+	for i := range n {
+		det = mulMod(det, matrix[i][i], m.Modulus)
+	}
+
+	return det, nil
+}
+
+// reduces [A | B] with invertible A to [I | X] and returns X
+func solveMod(a, b [][]int64, modulus int64) ([][]int64, error) {
+	n, cols := len(a), len(b[0])
+
+	matrix, memory, _ := Malloc[int64](n, n+cols)
+	for i := range n {
+		matrix[i] = memory[i*(n+cols) : (i+1)*(n+cols)]
+		copy(matrix[i], a[i])
+		copy(matrix[i][n:], b[i])
+	}
+
+	echelonMod(matrix, n, modulus)
+
+	for i := n - 1; i >= 0; i-- {
+		inverse, err := ModInverse(matrix[i][i], modulus)
+		if err != nil {
+			return nil, fmt.Errorf("matrix is not invertible modulo %d", modulus)
+		}
+
+		for j := i; j < n+cols; j++ {
+			matrix[i][j] = mulMod(matrix[i][j], inverse, modulus)
+		}
+
+		for k := range i {
+			factor := matrix[k][i]
+			for j := i; j < n+cols; j++ {
+				matrix[k][j] = subMod(matrix[k][j], mulMod(factor, matrix[i][j], modulus), modulus)
+			}
+		}
+	}
+
+	x, memory, _ := Malloc[int64](n, cols)
+	for i := range n {
+		x[i] = memory[i*cols : (i+1)*cols]
+		copy(x[i], matrix[i][n:])
+	}
+
+	return x, nil
+}
+
+// returns inverse of the matrix modulo Modulus
+func (m *ModularMatrix) Inverse() ([][]int64, error) {
+	if !m.Square || m.Augmented {
+		return nil, errors.New("matrix is not a square")
+	}
+
+	identity, memory, _ := Malloc[int64](m.Rows, m.Rows)
+	for i := range m.Rows {
+		identity[i] = memory[i*m.Rows : (i+1)*m.Rows]
+		identity[i][i] = 1
+	}
+
+	return solveMod(m.Data, identity, m.Modulus)
+}
+
+// solves augmented matrix modulo Modulus. the solution should be unique
+func (m *ModularMatrix) Solve() ([]int64, error) {
+	if !m.Augmented {
+		return nil, errors.New("matrix is not augmented")
+	}
+
+	if !m.Square {
+		return nil, errors.New("matrix is not a square")
+	}
+
+	b, memory, _ := Malloc[int64](m.Rows, 1)
+	for i := range m.Rows {
+		b[i] = memory[i : i+1]
+		b[i][0] = m.Data[i][m.Cols-1]
+	}
+
+	x, err := solveMod(m.coefficients(), b, m.Modulus)
+	if err != nil {
+		return nil, fmt.Errorf("system has no unique solution modulo %d", m.Modulus)
+	}
+
+	return transpose(x)[0], nil
+}
+
+func (m *ModularMatrix) Multiply(matrix *ModularMatrix) ([][]int64, error) {
+	if m.Modulus != matrix.Modulus {
+		return nil, fmt.Errorf("moduli %d and %d do not match", m.Modulus, matrix.Modulus)
+	}
+
+	if m.Cols != matrix.Rows {
+		return nil, fmt.Errorf(
+			"dimensions %dx%d and %dx%d do not match",
+			m.Rows, m.Cols, matrix.Rows, matrix.Cols,
+		)
+	}
+
+	newMatrix, memory, _ := Malloc[int64](m.Rows, matrix.Cols)
+	for i := range m.Rows {
+		newMatrix[i] = memory[i*matrix.Cols : (i+1)*matrix.Cols]
+		for j := range matrix.Cols {
+			value := int64(0)
+			for k := range m.Cols {
+				value = addMod(value, mulMod(m.Data[i][k], matrix.Data[k][j], m.Modulus), m.Modulus)
+			}
+			newMatrix[i][j] = value
+		}
+	}
+
+	return newMatrix, nil
+}
+
+func (m *ModularMatrix) String() string {
+	rows := make([]string, m.Rows)
+	for i, row := range m.Data {
+		rows[i] = fmt.Sprint(row)
+	}
+
+	return strings.Join(rows, "\n")
+}
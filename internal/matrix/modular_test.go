@@ -0,0 +1,174 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestModInverse(t *testing.T) {
+	tests := []struct {
+		a, modulus int64
+		want       int64
+		wantErr    bool
+	}{
+		{3, 7, 5, false},
+		{-1, 7, 6, false},
+		{9, 26, 3, false},
+		{13, 26, 0, true},
+		{0, 5, 0, true},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%d mod %d", test.a, test.modulus),
+			func(t *testing.T) {
+				ans, err := ModInverse(test.a, test.modulus)
+				if (err != nil) != test.wantErr {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if ans != test.want {
+					t.Errorf("got %d, want %d", ans, test.want)
+				}
+			})
+	}
+}
+
+func TestNewModularMatrixErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		value float64
+		want  string
+	}{
+		{"fraction", 1.5, "value 1.5 at (1, 2) is not an integer"},
+		{"nan", math.NaN(), "value NaN at (1, 2) is not an integer"},
+		{"too large", 1e19, "value 1e+19 at (1, 2) is out of the int64 range"},
+		{"too small", -1e300, "value -1e+300 at (1, 2) is out of the int64 range"},
+		{"infinity", math.Inf(1), "value +Inf at (1, 2) is out of the int64 range"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				_, err := NewModularMatrix([][]float64{{1, test.value}}, 7, false)
+				if err == nil || err.Error() != test.want {
+					t.Errorf("got %v, want %q", err, test.want)
+				}
+			})
+	}
+
+	matrix, err := NewModularMatrix([][]float64{{math.MinInt64, 1 << 62}}, 7, false)
+	if err != nil || matrix.Data[0][0] != 6 || matrix.Data[0][1] != 4 {
+		t.Errorf("got %v, %v", matrix, err)
+	}
+}
+
+func TestModularDeterminant(t *testing.T) {
+	tests := []struct {
+		name    string
+		matrix  [][]float64
+		modulus int64
+		want    int64
+	}{
+		{"hill key", [][]float64{{3, 3}, {2, 5}}, 26, 9},
+		{"negative values", [][]float64{{-1, 2}, {3, -4}}, 7, 5},
+		{"singular mod 2", [][]float64{{1, 1}, {1, 3}}, 2, 0},
+		{"3x3 mod 26", [][]float64{{6, 24, 1}, {13, 16, 10}, {20, 17, 15}}, 26, 25},
+		{"3x3 mod 7", matrix6, 7, 0},
+		{"large modulus", [][]float64{{2, 1}, {1, 2}}, 1<<61 - 1, 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				matrix, err := NewModularMatrix(test.matrix, test.modulus, false)
+				if err != nil {
+					t.Fatal(err)
+				}
+				ans, err := matrix.Determinant()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if ans != test.want {
+					t.Errorf("got %d, want %d", ans, test.want)
+				}
+			})
+	}
+}
+
+func TestModularInverse(t *testing.T) {
+	tests := []struct {
+		name    string
+		matrix  [][]float64
+		modulus int64
+		want    string
+		wantErr bool
+	}{
+		{"hill key", [][]float64{{3, 3}, {2, 5}}, 26, "15 17\n20 9\n", false},
+		{"3x3 mod 26", [][]float64{{6, 24, 1}, {13, 16, 10}, {20, 17, 15}}, 26,
+			"8 5 10\n21 8 21\n21 12 8\n", false},
+		{"not coprime", [][]float64{{2, 0}, {0, 1}}, 26, "", true},
+		{"singular", matrix6, 7, "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				matrix, _ := NewModularMatrix(test.matrix, test.modulus, false)
+				inverse, err := matrix.Inverse()
+				if (err != nil) != test.wantErr {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if ans := MatrixToString(inverse, " "); ans != test.want {
+					t.Errorf("\ngot:\n%s\nwant:\n%s", ans, test.want)
+				}
+				if err != nil {
+					return
+				}
+
+				other := &ModularMatrix{Data: inverse, Rows: matrix.Rows, Cols: matrix.Cols, Modulus: matrix.Modulus}
+				product, _ := matrix.Multiply(other)
+				if ans := MatrixToString(product, " "); ans != "1 0\n0 1\n" && ans != "1 0 0\n0 1 0\n0 0 1\n" {
+					t.Errorf("product is not identity:\n%s", ans)
+				}
+			})
+	}
+}
+
+func TestModularSolve(t *testing.T) {
+	tests := []struct {
+		name    string
+		matrix  [][]float64
+		modulus int64
+		want    string
+		wantErr bool
+	}{
+		// 2x + y = 1, x + 3y = 4 (mod 7)  =>  x = 4, y = 0
+		{"mod 7", [][]float64{{2, 1, 1}, {1, 3, 4}}, 7, "4 0", false},
+		{"mod 26", [][]float64{{3, 3, 0}, {2, 5, 7}}, 26, "15 11", false},
+		{"no unique solution", [][]float64{{1, 1, 1}, {2, 2, 2}}, 5, "", true},
+		{"1x1", [][]float64{{2, 1}}, 5, "3", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				matrix, _ := NewModularMatrix(test.matrix, test.modulus, true)
+				roots, err := matrix.Solve()
+				if (err != nil) != test.wantErr {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if ans := ArrayToString(roots, " "); ans != test.want {
+					t.Errorf("got %q, want %q", ans, test.want)
+				}
+			})
+	}
+}
+
+func TestNewModularMatrix(t *testing.T) {
+	if _, err := NewModularMatrix([][]float64{{0.5}}, 7, false); err == nil {
+		t.Error("expected error for non-integer value")
+	}
+	if _, err := NewModularMatrix([][]float64{{1}}, 1, false); err == nil {
+		t.Error("expected error for modulus 1")
+	}
+}
@@ -0,0 +1,262 @@
+package matrix
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const matrixMarketBanner = "%%MatrixMarket"
+
+// dense matrices with more values are not read from sparse and binary files
+const maxDenseValues = 1 << 24
+
+// reads matrix in the MatrixMarket exchange format. array and coordinate
+// formats are supported with real, integer or pattern values and general,
+// symmetric or skew-symmetric structure. lines after "%" are comments
+func ReadMatrixMarket(r io.Reader) ([][]float64, error) {
+	reader := bufio.NewReader(r)
+
+	header, err := reader.ReadString('\n')
+	if err != nil && (err != io.EOF || header == "") {
+		return nil, errors.New("error: data is empty")
+	}
+
+	fields := strings.Fields(header)
+	if len(fields) != 5 || !strings.EqualFold(fields[0], matrixMarketBanner) || !strings.EqualFold(fields[1], "matrix") {
+		return nil, &ParseError{1, 1, fmt.Errorf("header should be %q", matrixMarketBanner+" matrix format field symmetry")}
+	}
+
+	format, field, symmetry := strings.ToLower(fields[2]), strings.ToLower(fields[3]), strings.ToLower(fields[4])
+	switch {
+	case format != "coordinate" && format != "array":
+		return nil, &ParseError{1, 1, fmt.Errorf("format %q is not supported", format)}
+	case field != "real" && field != "double" && field != "integer" && field != "pattern":
+		return nil, &ParseError{1, 1, fmt.Errorf("field %q is not supported", field)}
+	case symmetry != "general" && symmetry != "symmetric" && symmetry != "skew-symmetric":
+		return nil, &ParseError{1, 1, fmt.Errorf("symmetry %q is not supported", symmetry)}
+	case format == "array" && field == "pattern":
+		return nil, &ParseError{1, 1, errors.New("array format can not have pattern field")}
+	}
+
+	line := 1
+	// returns fields of the next line that is not a comment or blank,
+	// nil fields is the end of the file
+	next := func() ([]string, []int, error) {
+		for {
+			text, err := reader.ReadString('\n')
+			if err == io.EOF && text == "" {
+				return nil, nil, nil
+			}
+			if err != nil && err != io.EOF {
+				return nil, nil, err
+			}
+			line++
+
+			if strings.HasPrefix(strings.TrimSpace(text), "%") {
+				continue
+			}
+			if fields, columns := splitFields(text); len(fields) > 0 {
+				return fields, columns, nil
+			}
+		}
+	}
+
+	parseInt := func(token string, column int) (int, error) {
+		value, err := strconv.Atoi(token)
+		if err != nil || value < 0 {
+			return 0, &ParseError{line, column, fmt.Errorf("%q is not a size or an index", token)}
+		}
+		return value, nil
+	}
+
+	parseValue := func(token string, column int) (float64, error) {
+		var value float64
+		var err error
+		if field == "integer" {
+			var integer int64
+			integer, err = strconv.ParseInt(token, 10, 64)
+			value = float64(integer)
+		} else {
+			value, err = strconv.ParseFloat(token, 64)
+		}
+		if err != nil {
+			return 0, &ParseError{line, column, fmt.Errorf("%q is not a number", token)}
+		}
+		return value, nil
+	}
+
+	sizes, columns, err := next()
+	if err != nil {
+		return nil, err
+	}
+	if sizes == nil {
+		return nil, errors.New("error: size is missing")
+	}
+
+	expected := 2
+	if format == "coordinate" {
+		expected = 3
+	}
+	if len(sizes) != expected {
+		return nil, &ParseError{line, columns[0], fmt.Errorf("size line has %d values, expected %d", len(sizes), expected)}
+	}
+
+	var size [3]int
+	for i, token := range sizes {
+		if size[i], err = parseInt(token, columns[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, cols := size[0], size[1]
+	switch {
+	case rows == 0 || cols == 0:
+		return nil, &ParseError{line, columns[0], errors.New("matrix is empty")}
+	case rows > maxDenseValues/cols:
+		return nil, &ParseError{line, columns[0], fmt.Errorf("matrix %dx%d is too large", rows, cols)}
+	case symmetry != "general" && rows != cols:
+		return nil, &ParseError{line, columns[0], fmt.Errorf("%s matrix is not a square", symmetry)}
+	}
+
+	matrix, memory, _ := Malloc[float64](rows, cols)
+	for i := range rows {
+		matrix[i] = memory[(i * cols):((i + 1) * cols)]
+	}
+
+	// sets value and its mirror for symmetric matrices
+	set := func(i, j int, value float64) {
+		matrix[i][j] = value
+		switch symmetry {
+		case "symmetric":
+			matrix[j][i] = value
+		case "skew-symmetric":
+			matrix[j][i] = -value
+		}
+	}
+
+	if format == "array" {
+		// values go by columns, only the lower triangle for symmetric
+		var positions [][2]int
+		for j := range cols {
+			for i := range rows {
+				if symmetry == "general" || i > j || (i == j && symmetry == "symmetric") {
+					positions = append(positions, [2]int{i, j})
+				}
+			}
+		}
+
+		for k := 0; k < len(positions); {
+			values, columns, err := next()
+			if err != nil {
+				return nil, err
+			}
+			if values == nil {
+				return nil, fmt.Errorf("error: expected %d values, got %d", len(positions), k)
+			}
+
+			for c, token := range values {
+				if k == len(positions) {
+					return nil, &ParseError{line, columns[c], fmt.Errorf("expected %d values", len(positions))}
+				}
+
+				value, err := parseValue(token, columns[c])
+				if err != nil {
+					return nil, err
+				}
+				set(positions[k][0], positions[k][1], value)
+				k++
+			}
+		}
+	} else {
+		entries := size[2]
+		width := 3
+		if field == "pattern" {
+			width = 2
+		}
+
+		for k := range entries {
+			values, columns, err := next()
+			if err != nil {
+				return nil, err
+			}
+			if values == nil {
+				return nil, fmt.Errorf("error: expected %d entries, got %d", entries, k)
+			}
+			if len(values) != width {
+				return nil, &ParseError{line, columns[0], fmt.Errorf("entry has %d values, expected %d", len(values), width)}
+			}
+
+			i, err := parseInt(values[0], columns[0])
+			if err != nil {
+				return nil, err
+			}
+			j, err := parseInt(values[1], columns[1])
+			if err != nil {
+				return nil, err
+			}
+			if i < 1 || i > rows || j < 1 || j > cols {
+				return nil, &ParseError{line, columns[0], fmt.Errorf("entry (%d, %d) is out of the matrix %dx%d", i, j, rows, cols)}
+			}
+
+			value := 1.0
+			if field != "pattern" {
+				if value, err = parseValue(values[2], columns[2]); err != nil {
+					return nil, err
+				}
+			}
+			if symmetry == "skew-symmetric" && i == j && value != 0 {
+				return nil, &ParseError{line, columns[0], errors.New("diagonal of skew-symmetric matrix should be zero")}
+			}
+
+			set(i-1, j-1, value)
+		}
+	}
+
+	if values, columns, err := next(); err != nil {
+		return nil, err
+	} else if values != nil {
+		return nil, &ParseError{line, columns[0], errors.New("unexpected data after the matrix")}
+	}
+
+	return matrix, nil
+}
+
+// writes matrix in the MatrixMarket exchange format with real values.
+// coordinate format has only nonzero values, array format has all of them
+func WriteMatrixMarket(w io.Writer, matrix [][]float64, coordinate bool) error {
+	writer := bufio.NewWriter(w)
+	rows, cols := len(matrix), len(matrix[0])
+
+	if coordinate {
+		entries := 0
+		for _, row := range matrix {
+			for _, value := range row {
+				if value != 0 {
+					entries++
+				}
+			}
+		}
+
+		fmt.Fprintf(writer, "%s matrix coordinate real general\n%d %d %d\n", matrixMarketBanner, rows, cols, entries)
+		for j := range cols {
+			for i := range rows {
+				if matrix[i][j] != 0 {
+					fmt.Fprintf(writer, "%d %d %s\n", i+1, j+1, FormatNumber(matrix[i][j]))
+				}
+			}
+		}
+	} else {
+		fmt.Fprintf(writer, "%s matrix array real general\n%d %d\n", matrixMarketBanner, rows, cols)
+		for j := range cols {
+			for i := range rows {
+				fmt.Fprintln(writer, FormatNumber(matrix[i][j]))
+			}
+		}
+	}
+
+	return writer.Flush()
+}
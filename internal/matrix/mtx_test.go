@@ -0,0 +1,107 @@
+package matrix
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadMatrixMarket(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  [][]float64
+	}{
+		{"array", "%%MatrixMarket matrix array real general\n% comment\n2 3\n1\n4\n2\n5\n3\n6\n", [][]float64{{1, 2, 3}, {4, 5, 6}}},
+		{"array values in line", "%%MatrixMarket matrix array integer general\n2 2\n1 3\n\n2 4\n", [][]float64{{1, 2}, {3, 4}}},
+		{"array symmetric", "%%MatrixMarket matrix array real symmetric\n2 2\n1\n2\n3\n", [][]float64{{1, 2}, {2, 3}}},
+		{"array skew", "%%MatrixMarket matrix array real skew-symmetric\n3 3\n1\n2\n3\n", [][]float64{{0, -1, -2}, {1, 0, -3}, {2, 3, 0}}},
+		{"coordinate", "%%MatrixMarket matrix coordinate real general\n%\n2 3 3\n1 1 1.5\n2 3 -2\n1 2 1e2\n", [][]float64{{1.5, 100, 0}, {0, 0, -2}}},
+		{"coordinate symmetric", "%%MatrixMarket matrix coordinate integer symmetric\n2 2 2\n1 1 4\n2 1 7\n", [][]float64{{4, 7}, {7, 0}}},
+		{"coordinate skew", "%%MatrixMarket matrix coordinate double skew-symmetric\n2 2 1\n2 1 3\n", [][]float64{{0, -3}, {3, 0}}},
+		{"pattern", "%%matrixmarket MATRIX Coordinate Pattern General\n2 2 2\n1 2\n2 1\n", [][]float64{{0, 1}, {1, 0}}},
+		{"no newline", "%%MatrixMarket matrix coordinate real general\n1 1 1\n1 1 9", [][]float64{{9}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				ans, err := ReadMatrixMarket(strings.NewReader(test.input))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				got := MatrixToString(ans, " ")
+				want := MatrixToString(test.want, " ")
+				if got != want {
+					t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+				}
+			})
+	}
+}
+
+func TestReadMatrixMarketErrors(t *testing.T) {
+	const coordinate = "%%MatrixMarket matrix coordinate real general\n"
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"empty", "", "error: data is empty"},
+		{"banner", "1 2\n3 4\n", "line 1, column 1: header should be \"%%MatrixMarket matrix format field symmetry\""},
+		{"complex", "%%MatrixMarket matrix coordinate complex general\n", "line 1, column 1: field \"complex\" is not supported"},
+		{"hermitian", "%%MatrixMarket matrix array real hermitian\n", "line 1, column 1: symmetry \"hermitian\" is not supported"},
+		{"array pattern", "%%MatrixMarket matrix array pattern general\n", "line 1, column 1: array format can not have pattern field"},
+		{"no size", coordinate + "% only comment\n", "error: size is missing"},
+		{"size", coordinate + "2 2\n", "line 2, column 1: size line has 2 values, expected 3"},
+		{"bad size", coordinate + "2 -2 1\n", "line 2, column 3: \"-2\" is not a size or an index"},
+		{"too large", coordinate + "100000 100000 1\n", "line 2, column 1: matrix 100000x100000 is too large"},
+		{"not square", "%%MatrixMarket matrix array real symmetric\n2 3\n", "line 2, column 1: symmetric matrix is not a square"},
+		{"out of matrix", coordinate + "2 2 1\n3 1 1\n", "line 3, column 1: entry (3, 1) is out of the matrix 2x2"},
+		{"short entry", coordinate + "2 2 1\n1 1\n", "line 3, column 1: entry has 2 values, expected 3"},
+		{"bad value", coordinate + "2 2 1\n1 1  x\n", "line 3, column 6: \"x\" is not a number"},
+		{"integer", "%%MatrixMarket matrix array integer general\n1 1\n1.5\n", "line 3, column 1: \"1.5\" is not a number"},
+		{"few entries", coordinate + "2 2 2\n1 1 1\n", "error: expected 2 entries, got 1"},
+		{"few values", "%%MatrixMarket matrix array real general\n2 1\n1\n", "error: expected 2 values, got 1"},
+		{"many values", "%%MatrixMarket matrix array real general\n2 1\n1 2 3\n", "line 3, column 5: expected 2 values"},
+		{"extra", coordinate + "1 1 1\n1 1 1\n1 1 1\n", "line 4, column 1: unexpected data after the matrix"},
+		{"skew diagonal", "%%MatrixMarket matrix coordinate real skew-symmetric\n2 2 1\n1 1 1\n", "line 3, column 1: diagonal of skew-symmetric matrix should be zero"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				_, err := ReadMatrixMarket(strings.NewReader(test.input))
+				if err == nil || err.Error() != test.want {
+					t.Errorf("got %v, want %q", err, test.want)
+				}
+			})
+	}
+}
+
+func TestWriteMatrixMarket(t *testing.T) {
+	data := [][]float64{{1, 0}, {0, -2.5}, {3, 0}}
+
+	tests := []struct {
+		coordinate bool
+		want       string
+	}{
+		{false, "%%MatrixMarket matrix array real general\n3 2\n1\n0\n3\n0\n-2.5\n0\n"},
+		{true, "%%MatrixMarket matrix coordinate real general\n3 2 3\n1 1 1\n3 1 3\n2 2 -2.5\n"},
+	}
+
+	for _, test := range tests {
+		var sb strings.Builder
+		if err := WriteMatrixMarket(&sb, data, test.coordinate); err != nil {
+			t.Fatal(err)
+		}
+		if sb.String() != test.want {
+			t.Errorf("got %q, want %q", sb.String(), test.want)
+		}
+
+		ans, err := ReadMatrixMarket(strings.NewReader(sb.String()))
+		if err != nil || MatrixToString(ans, " ") != MatrixToString(data, " ") {
+			t.Errorf("matrix is not read back: %v, %v", ans, err)
+		}
+	}
+}
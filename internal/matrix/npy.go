@@ -0,0 +1,254 @@
+package matrix
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const npyMagic = "\x93NUMPY"
+
+// header with the padding is aligned to this size
+const npyAlignment = 64
+
+// longer headers are considered broken
+const maxNPYHeader = 1 << 16
+
+var (
+	npyDescr   = regexp.MustCompile(`['"]descr['"]\s*:\s*['"]([^'"]*)['"]`)
+	npyFortran = regexp.MustCompile(`['"]fortran_order['"]\s*:\s*(True|False)`)
+	npyShape   = regexp.MustCompile(`['"]shape['"]\s*:\s*\(([^)]*)\)`)
+)
+
+// reads matrix from the NumPy .npy format. arrays should have float64 or
+// int64 values and 1 or 2 dimensions, 1-dimensional array is a row.
+// int64 values are converted to float64
+func ReadNPY(r io.Reader) ([][]float64, error) {
+	prefix := make([]byte, len(npyMagic)+2)
+	if _, err := io.ReadFull(r, prefix); err != nil {
+		return nil, errors.New("error: data is not a .npy array")
+	}
+	if string(prefix[:len(npyMagic)]) != npyMagic {
+		return nil, errors.New("error: data is not a .npy array")
+	}
+
+	var length uint32
+	switch major := prefix[len(npyMagic)]; major {
+	case 1:
+		var short uint16
+		if err := binary.Read(r, binary.LittleEndian, &short); err != nil {
+			return nil, err
+		}
+		length = uint32(short)
+	case 2, 3:
+		if err := binary.Read(r, binary.LittleEndian, &length); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf(".npy version %d is not supported", major)
+	}
+	if length > maxNPYHeader {
+		return nil, fmt.Errorf(".npy header of %d bytes is too long", length)
+	}
+
+	header := make([]byte, length)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+
+	descr, fortran, rows, cols, err := parseNPYHeader(string(header))
+	if err != nil {
+		return nil, err
+	}
+
+	var order binary.ByteOrder = binary.LittleEndian
+	if descr[0] == '>' {
+		order = binary.BigEndian
+	}
+
+	// values in the order of the file
+	memory := make([]float64, rows*cols)
+	if descr[1:] == "f8" {
+		err = binary.Read(r, order, memory)
+	} else {
+		integers := make([]int64, rows*cols)
+		err = binary.Read(r, order, integers)
+		for i, value := range integers {
+			memory[i] = float64(value)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error: .npy data is incomplete: %w", err)
+	}
+
+	matrix, values, _ := Malloc[float64](rows, cols)
+	for i := range rows {
+		matrix[i] = values[(i * cols):((i + 1) * cols)]
+		for j := range cols {
+			if fortran {
+				matrix[i][j] = memory[j*rows+i]
+			} else {
+				matrix[i][j] = memory[i*cols+j]
+			}
+		}
+	}
+
+	return matrix, nil
+}
+
+// parses header of the .npy format, the python dictionary literal
+func parseNPYHeader(header string) (string, bool, int, int, error) {
+	descr := npyDescr.FindStringSubmatch(header)
+	fortran := npyFortran.FindStringSubmatch(header)
+	shape := npyShape.FindStringSubmatch(header)
+	if descr == nil || fortran == nil || shape == nil {
+		return "", false, 0, 0, fmt.Errorf(".npy header %q is invalid", strings.TrimSpace(header))
+	}
+
+	// "=" is the native order, it is little endian on the common platforms
+	dtype := strings.Replace(descr[1], "=", "<", 1)
+	if !slices.Contains([]string{"<f8", ">f8", "<i8", ">i8"}, dtype) {
+		return "", false, 0, 0, fmt.Errorf(".npy type %q is not supported, expected float64 or int64", descr[1])
+	}
+
+	var sizes []int
+	for _, field := range strings.Split(shape[1], ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		size, err := strconv.Atoi(field)
+		if err != nil || size < 0 {
+			return "", false, 0, 0, fmt.Errorf(".npy shape (%s) is invalid", shape[1])
+		}
+		sizes = append(sizes, size)
+	}
+
+	var rows, cols int
+	switch len(sizes) {
+	case 1:
+		rows, cols = 1, sizes[0]
+	case 2:
+		rows, cols = sizes[0], sizes[1]
+	default:
+		return "", false, 0, 0, fmt.Errorf(".npy array has %d dimensions, expected 1 or 2", len(sizes))
+	}
+
+	switch {
+	case rows == 0 || cols == 0:
+		return "", false, 0, 0, errors.New("error: data is empty")
+	case rows > maxDenseValues/cols:
+		return "", false, 0, 0, fmt.Errorf("matrix %dx%d is too large", rows, cols)
+	}
+
+	return dtype, fortran[1] == "True", rows, cols, nil
+}
+
+// writes matrix in the NumPy .npy format version 1.0. float64 values
+// are written as "<f8", integer values as "<i8"
+func WriteNPY[number int | int64 | float64](w io.Writer, matrix [][]number) error {
+	rows, cols := len(matrix), len(matrix[0])
+
+	descr := "<i8"
+	if _, ok := any(matrix[0][0]).(float64); ok {
+		descr = "<f8"
+	}
+
+	header := fmt.Sprintf("{'descr': '%s', 'fortran_order': False, 'shape': (%d, %d), }", descr, rows, cols)
+
+	// magic, version and length of the header go before it, newline ends it
+	padding := npyAlignment - (len(npyMagic)+4+len(header)+1)%npyAlignment
+	header += strings.Repeat(" ", padding%npyAlignment) + "\n"
+
+	var buffer bytes.Buffer
+	buffer.WriteString(npyMagic)
+	buffer.Write([]byte{1, 0})
+	binary.Write(&buffer, binary.LittleEndian, uint16(len(header)))
+	buffer.WriteString(header)
+
+	values := make([]byte, 0, rows*cols*8)
+	for _, row := range matrix {
+		for _, value := range row {
+			if descr == "<f8" {
+				values = binary.LittleEndian.AppendUint64(values, math.Float64bits(float64(value)))
+			} else {
+				values = binary.LittleEndian.AppendUint64(values, uint64(int64(value)))
+			}
+		}
+	}
+	buffer.Write(values)
+
+	_, err := buffer.WriteTo(w)
+	return err
+}
+
+// reads named matrices from the NumPy .npz archive of .npy arrays.
+// names are without the ".npy" extension, like in numpy.load
+func ReadNPZ(r io.Reader) (map[string][][]float64, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("error: data is not a .npz archive: %w", err)
+	}
+
+	matrices := make(map[string][][]float64, len(archive.File))
+	for _, file := range archive.File {
+		name := strings.TrimSuffix(file.Name, ".npy")
+
+		entry, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+
+		matrix, err := ReadNPY(entry)
+		entry.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+
+		matrices[name] = matrix
+	}
+
+	if len(matrices) == 0 {
+		return nil, errors.New("error: .npz archive is empty")
+	}
+
+	return matrices, nil
+}
+
+// writes named matrices to the NumPy .npz archive in the order of names
+func WriteNPZ(w io.Writer, matrices map[string][][]float64) error {
+	archive := zip.NewWriter(w)
+
+	names := make([]string, 0, len(matrices))
+	for name := range matrices {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		entry, err := archive.Create(name + ".npy")
+		if err != nil {
+			return err
+		}
+
+		if err := WriteNPY(entry, matrices[name]); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
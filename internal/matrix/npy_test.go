@@ -0,0 +1,166 @@
+package matrix
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+)
+
+// returns .npy file with the header and values in the byte order
+func npyBytes(major byte, header string, order binary.ByteOrder, values any) []byte {
+	var buffer bytes.Buffer
+	buffer.WriteString(npyMagic)
+	buffer.Write([]byte{major, 0})
+	if major == 1 {
+		binary.Write(&buffer, binary.LittleEndian, uint16(len(header)))
+	} else {
+		binary.Write(&buffer, binary.LittleEndian, uint32(len(header)))
+	}
+	buffer.WriteString(header)
+	binary.Write(&buffer, order, values)
+	return buffer.Bytes()
+}
+
+func TestReadNPY(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+		want  [][]float64
+	}{
+		{"float64", npyBytes(1, "{'descr': '<f8', 'fortran_order': False, 'shape': (2, 3), }\n",
+			binary.LittleEndian, []float64{1, 2, 3, 4, 5, 0.1}), [][]float64{{1, 2, 3}, {4, 5, 0.1}}},
+		{"int64", npyBytes(1, "{'descr': '<i8', 'fortran_order': False, 'shape': (2, 2), }\n",
+			binary.LittleEndian, []int64{1, -2, 3, 1 << 40}), [][]float64{{1, -2}, {3, 1 << 40}}},
+		{"big endian", npyBytes(1, "{'descr': '>f8', 'fortran_order': False, 'shape': (1, 2), }\n",
+			binary.BigEndian, []float64{1.5, -3}), [][]float64{{1.5, -3}}},
+		{"fortran", npyBytes(1, "{'descr': '<f8', 'fortran_order': True, 'shape': (2, 3), }\n",
+			binary.LittleEndian, []float64{1, 4, 2, 5, 3, 6}), [][]float64{{1, 2, 3}, {4, 5, 6}}},
+		{"vector", npyBytes(1, "{'descr': '<i8', 'fortran_order': False, 'shape': (3,), }\n",
+			binary.LittleEndian, []int64{1, 2, 3}), [][]float64{{1, 2, 3}}},
+		{"version 2", npyBytes(2, "{\"descr\": \"=f8\", \"shape\": (1, 1), \"fortran_order\": False}\n",
+			binary.LittleEndian, []float64{7}), [][]float64{{7}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				ans, err := ReadNPY(bytes.NewReader(test.input))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				got := MatrixToString(ans, " ")
+				want := MatrixToString(test.want, " ")
+				if got != want {
+					t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+				}
+			})
+	}
+}
+
+func TestReadNPYErrors(t *testing.T) {
+	header := func(descr, shape string) string {
+		return "{'descr': '" + descr + "', 'fortran_order': False, 'shape': " + shape + ", }\n"
+	}
+
+	tests := []struct {
+		name  string
+		input []byte
+		want  string
+	}{
+		{"text", []byte("1 2\n3 4\n"), "error: data is not a .npy array"},
+		{"version", npyBytes(4, "", binary.LittleEndian, []float64{}), ".npy version 4 is not supported"},
+		{"header", npyBytes(1, "{'descr': '<f8'}\n", binary.LittleEndian, []float64{}), ".npy header \"{'descr': '<f8'}\" is invalid"},
+		{"type", npyBytes(1, header("<f4", "(1, 1)"), binary.LittleEndian, []float32{1}),
+			".npy type \"<f4\" is not supported, expected float64 or int64"},
+		{"dimensions", npyBytes(1, header("<f8", "(1, 1, 1)"), binary.LittleEndian, []float64{1}),
+			".npy array has 3 dimensions, expected 1 or 2"},
+		{"scalar", npyBytes(1, header("<f8", "()"), binary.LittleEndian, []float64{1}),
+			".npy array has 0 dimensions, expected 1 or 2"},
+		{"empty", npyBytes(1, header("<f8", "(0, 2)"), binary.LittleEndian, []float64{}), "error: data is empty"},
+		{"too large", npyBytes(1, header("<f8", "(100000, 100000)"), binary.LittleEndian, []float64{}),
+			"matrix 100000x100000 is too large"},
+		{"incomplete", npyBytes(1, header("<f8", "(2, 2)"), binary.LittleEndian, []float64{1, 2, 3}),
+			"error: .npy data is incomplete: unexpected EOF"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				_, err := ReadNPY(bytes.NewReader(test.input))
+				if err == nil || err.Error() != test.want {
+					t.Errorf("got %v, want %q", err, test.want)
+				}
+			})
+	}
+}
+
+func TestWriteNPY(t *testing.T) {
+	data := [][]float64{{1, 2}, {3, 4.5}}
+
+	var buffer bytes.Buffer
+	if err := WriteNPY(&buffer, data); err != nil {
+		t.Fatal(err)
+	}
+
+	// the same as numpy.save writes
+	header := "{'descr': '<f8', 'fortran_order': False, 'shape': (2, 2), }"
+	want := npyBytes(1, header+strings.Repeat(" ", 58)+"\n", binary.LittleEndian, []float64{1, 2, 3, 4.5})
+	if !bytes.Equal(buffer.Bytes(), want) {
+		t.Errorf("got %q, want %q", buffer.Bytes(), want)
+	}
+	if buffer.Len()%npyAlignment != 32 {
+		t.Errorf("header is not aligned, file has %d bytes", buffer.Len())
+	}
+
+	ans, err := ReadNPY(&buffer)
+	if err != nil || MatrixToString(ans, " ") != MatrixToString(data, " ") {
+		t.Errorf("matrix is not read back: %v, %v", ans, err)
+	}
+
+	buffer.Reset()
+	WriteNPY(&buffer, [][]int64{{-1, 1 << 40}})
+	if !bytes.Contains(buffer.Bytes(), []byte("'descr': '<i8'")) {
+		t.Errorf("got header %q", buffer.Bytes()[:64])
+	}
+	ans, err = ReadNPY(&buffer)
+	if err != nil || ArrayToString(ans[0], " ") != "-1 1.099511627776e+12" {
+		t.Errorf("got %v, %v", ans, err)
+	}
+}
+
+func TestNPZ(t *testing.T) {
+	matrices := map[string][][]float64{
+		"A": {{1, 2}, {3, 4}},
+		"b": {{5}, {6}},
+	}
+
+	var buffer bytes.Buffer
+	if err := WriteNPZ(&buffer, matrices); err != nil {
+		t.Fatal(err)
+	}
+	archive := bytes.Clone(buffer.Bytes())
+
+	ans, err := ReadNPZ(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ans) != 2 || MatrixToString(ans["A"], " ") != "1 2\n3 4\n" || MatrixToString(ans["b"], " ") != "5\n6\n" {
+		t.Errorf("got %v", ans)
+	}
+
+	if _, err := ReadFile("matrices.npz", bytes.NewReader(archive)); err == nil || err.Error() != ".npz archive has 2 arrays, expected one: A, b" {
+		t.Errorf("got %v", err)
+	}
+
+	if _, err := ReadNPZ(strings.NewReader("1 2\n")); err == nil {
+		t.Error("expected error for text input")
+	}
+
+	buffer.Reset()
+	WriteNPZ(&buffer, nil)
+	if _, err := ReadNPZ(&buffer); err == nil || err.Error() != "error: .npz archive is empty" {
+		t.Errorf("got %v", err)
+	}
+}
@@ -0,0 +1,167 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+)
+
+func dimensionError(m, matrix *Matrix) error {
+	return fmt.Errorf(
+		"dimensions %dx%d and %dx%d do not match",
+		m.Rows, m.Cols, matrix.Rows, matrix.Cols,
+	)
+}
+
+func (m *Matrix) elementwise(matrix *Matrix, operation func(a, b float64) float64) ([][]float64, error) {
+	if m.Rows != matrix.Rows || m.Cols != matrix.Cols {
+		return nil, dimensionError(m, matrix)
+	}
+
+	newMatrix, memory, err := Malloc[float64](m.Rows, m.Cols)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range m.Rows {
+		newMatrix[i] = memory[i*m.Cols : (i+1)*m.Cols]
+		for j := range m.Cols {
+			newMatrix[i][j] = operation(m.Data[i][j], matrix.Data[i][j])
+		}
+	}
+
+	return newMatrix, nil
+}
+
+// returns m + matrix. dimensions of the matrices should be equal
+func (m *Matrix) Add(matrix *Matrix) ([][]float64, error) {
+	return m.elementwise(matrix, func(a, b float64) float64 { return a + b })
+}
+
+// returns m - matrix. dimensions of the matrices should be equal
+func (m *Matrix) Subtract(matrix *Matrix) ([][]float64, error) {
+	return m.elementwise(matrix, func(a, b float64) float64 { return a - b })
+}
+
+// returns elementwise (Hadamard) product. dimensions of the matrices should be equal
+func (m *Matrix) Hadamard(matrix *Matrix) ([][]float64, error) {
+	return m.elementwise(matrix, func(a, b float64) float64 { return a * b })
+}
+
+func (m *Matrix) Scale(scalar float64) [][]float64 {
+	newMatrix, memory, _ := Malloc[float64](m.Rows, m.Cols)
+	for i := range m.Rows {
+		newMatrix[i] = memory[i*m.Cols : (i+1)*m.Cols]
+		for j := range m.Cols {
+			newMatrix[i][j] = m.Data[i][j] * scalar
+		}
+	}
+
+	return newMatrix
+}
+
+// returns Kronecker product with dimensions (m.Rows * matrix.Rows)x(m.Cols * matrix.Cols)
+func (m *Matrix) Kronecker(matrix *Matrix) [][]float64 {
+	rows, cols := m.Rows*matrix.Rows, m.Cols*matrix.Cols
+
+	newMatrix, memory, _ := Malloc[float64](rows, cols)
+	for i := range rows {
+		newMatrix[i] = memory[i*cols : (i+1)*cols]
+	}
+
+	for i := range m.Rows {
+		for j := range m.Cols {
+			for k := range matrix.Rows {
+				for l := range matrix.Cols {
+					newMatrix[i*matrix.Rows+k][j*matrix.Cols+l] = m.Data[i][j] * matrix.Data[k][l]
+				}
+			}
+		}
+	}
+
+	return newMatrix
+}
+
+// places matrix to the right of m. row count should be equal
+func (m *Matrix) ConcatHorizontal(matrix *Matrix) ([][]float64, error) {
+	return Block([][]*Matrix{{m, matrix}})
+}
+
+// places matrix below m. column count should be equal
+func (m *Matrix) ConcatVertical(matrix *Matrix) ([][]float64, error) {
+	return Block([][]*Matrix{{m}, {matrix}})
+}
+
+// assembles a matrix from blocks
+//
+//	[[A, B],
+//	 [C, D]]
+//
+// all blocks in one block row should have equal row count
+// and all blocks in one block column should have equal column count
+func Block(blocks [][]*Matrix) ([][]float64, error) {
+	if len(blocks) == 0 || len(blocks[0]) == 0 {
+		return nil, errors.New("no blocks")
+	}
+
+	blockCols := len(blocks[0])
+	heights := make([]int, len(blocks))
+	widths := make([]int, blockCols)
+
+	for i, blockRow := range blocks {
+		if len(blockRow) != blockCols {
+			return nil, fmt.Errorf(
+				"block row %d has %d blocks, expected %d", i+1, len(blockRow), blockCols,
+			)
+		}
+
+		for j, block := range blockRow {
+			if block == nil {
+				return nil, fmt.Errorf("block (%d, %d) is nil", i+1, j+1)
+			}
+
+			if j == 0 {
+				heights[i] = block.Rows
+			} else if block.Rows != heights[i] {
+				return nil, fmt.Errorf(
+					"block (%d, %d) has %d rows, expected %d", i+1, j+1, block.Rows, heights[i],
+				)
+			}
+
+			if i == 0 {
+				widths[j] = block.Cols
+			} else if block.Cols != widths[j] {
+				return nil, fmt.Errorf(
+					"block (%d, %d) has %d columns, expected %d", i+1, j+1, block.Cols, widths[j],
+				)
+			}
+		}
+	}
+
+	rows, cols := 0, 0
+	for _, height := range heights {
+		rows += height
+	}
+	for _, width := range widths {
+		cols += width
+	}
+
+	newMatrix, memory, err := Malloc[float64](rows, cols)
+	if err != nil {
+		return nil, err
+	}
+
+	row := 0
+	for i, blockRow := range blocks {
+		for k := range heights[i] {
+			newMatrix[row+k] = memory[(row+k)*cols : (row+k+1)*cols]
+			col := 0
+			for j, block := range blockRow {
+				copy(newMatrix[row+k][col:col+widths[j]], block.Data[k])
+				col += widths[j]
+			}
+		}
+		row += heights[i]
+	}
+
+	return newMatrix, nil
+}
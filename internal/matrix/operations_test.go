@@ -0,0 +1,183 @@
+package matrix
+
+import (
+	"testing"
+)
+
+func TestElementwise(t *testing.T) {
+	tests := []struct {
+		name      string
+		matrix1   [][]float64
+		matrix2   [][]float64
+		operation string
+		want      string
+		wantErr   bool
+	}{
+		{"add rows", matrix5, matrix5, "add", "2 4 6\n", false},
+		{"add squares", matrix6, matrix4, "add", "1 2 3\n4 5 6\n7 8 9\n", false},
+		{"add mismatch", matrix5, matrix6, "add", "", true},
+		{"subtract squares", matrix6, matrix6, "subtract", "0 0 0\n0 0 0\n0 0 0\n", false},
+		{"subtract columns", matrix8, matrix7, "subtract", "1\n2\n3\n", false},
+		{"subtract mismatch", matrix8, matrix5, "subtract", "", true},
+		{"hadamard squares", matrix6, matrix6, "hadamard", "1 4 9\n16 25 36\n49 64 81\n", false},
+		{"hadamard mismatch", matrix6, matrix9, "hadamard", "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				matrix1, _ := NewMatrix(test.matrix1, false)
+				matrix2, _ := NewMatrix(test.matrix2, false)
+
+				var data [][]float64
+				var err error
+				switch test.operation {
+				case "add":
+					data, err = matrix1.Add(matrix2)
+				case "subtract":
+					data, err = matrix1.Subtract(matrix2)
+				case "hadamard":
+					data, err = matrix1.Hadamard(matrix2)
+				}
+
+				if (err != nil) != test.wantErr {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				ans := MatrixToString(data, " ")
+				if ans != test.want {
+					t.Errorf("\ngot:\n%s\nwant:\n%s", ans, test.want)
+				}
+			})
+	}
+}
+
+func TestScale(t *testing.T) {
+	tests := []struct {
+		name   string
+		matrix [][]float64
+		arg    float64
+		want   string
+	}{
+		{"arg 0 with row", matrix5, 0, "0 0 0\n"},
+		{"arg 2 with row", matrix5, 2, "2 4 6\n"},
+		{"arg -1 with three rows", matrix6, -1, "-1 -2 -3\n-4 -5 -6\n-7 -8 -9\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				matrix, _ := NewMatrix(test.matrix, false)
+				ans := MatrixToString(matrix.Scale(test.arg), " ")
+				if ans != test.want {
+					t.Errorf("\ngot:\n%s\nwant:\n%s", ans, test.want)
+				}
+			})
+	}
+}
+
+func TestKronecker(t *testing.T) {
+	tests := []struct {
+		name    string
+		matrix1 [][]float64
+		matrix2 [][]float64
+		want    string
+	}{
+		{"1 elem with row", matrix9, matrix5, "1 2 3\n"},
+		{"row with column", matrix5, matrix8, "1 2 3\n2 4 6\n3 6 9\n"},
+		{"2x2 with 2x2", [][]float64{{1, 2}, {3, 4}}, [][]float64{{0, 1}, {1, 0}},
+			"0 1 0 2\n1 0 2 0\n0 3 0 4\n3 0 4 0\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				matrix1, _ := NewMatrix(test.matrix1, false)
+				matrix2, _ := NewMatrix(test.matrix2, false)
+				ans := MatrixToString(matrix1.Kronecker(matrix2), " ")
+				if ans != test.want {
+					t.Errorf("\ngot:\n%s\nwant:\n%s", ans, test.want)
+				}
+			})
+	}
+}
+
+func TestConcat(t *testing.T) {
+	tests := []struct {
+		name       string
+		matrix1    [][]float64
+		matrix2    [][]float64
+		horizontal bool
+		want       string
+		wantErr    bool
+	}{
+		{"horizontal rows", matrix5, matrix5, true, "1 2 3 1 2 3\n", false},
+		{"horizontal columns", matrix8, matrix6, true, "1 1 2 3\n2 4 5 6\n3 7 8 9\n", false},
+		{"horizontal mismatch", matrix5, matrix6, true, "", true},
+		{"vertical rows", matrix5, matrix6, false, "1 2 3\n1 2 3\n4 5 6\n7 8 9\n", false},
+		{"vertical columns", matrix9, matrix8, false, "1\n1\n2\n3\n", false},
+		{"vertical mismatch", matrix8, matrix5, false, "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				matrix1, _ := NewMatrix(test.matrix1, false)
+				matrix2, _ := NewMatrix(test.matrix2, false)
+
+				var data [][]float64
+				var err error
+				if test.horizontal {
+					data, err = matrix1.ConcatHorizontal(matrix2)
+				} else {
+					data, err = matrix1.ConcatVertical(matrix2)
+				}
+
+				if (err != nil) != test.wantErr {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				ans := MatrixToString(data, " ")
+				if ans != test.want {
+					t.Errorf("\ngot:\n%s\nwant:\n%s", ans, test.want)
+				}
+			})
+	}
+}
+
+func TestBlock(t *testing.T) {
+	a, _ := NewMatrix([][]float64{{1, 2}, {3, 4}}, false)
+	b, _ := NewMatrix(matrix8, false)
+	c, _ := NewMatrix(matrix5, false)
+
+	tests := []struct {
+		name    string
+		blocks  [][]*Matrix
+		want    string
+		wantErr bool
+	}{
+		{"no blocks", nil, "", true},
+		{"single block", [][]*Matrix{{a}}, "1 2\n3 4\n", false},
+		{"2x2 blocks", [][]*Matrix{{a, a}, {a, a}},
+			"1 2 1 2\n3 4 3 4\n1 2 1 2\n3 4 3 4\n", false},
+		{"row height mismatch", [][]*Matrix{{a, b}}, "", true},
+		{"column width mismatch", [][]*Matrix{{a}, {c}}, "", true},
+		{"ragged blocks", [][]*Matrix{{a, a}, {a}}, "", true},
+		{"nil block", [][]*Matrix{{a, nil}}, "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				data, err := Block(test.blocks)
+				if (err != nil) != test.wantErr {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				ans := MatrixToString(data, " ")
+				if ans != test.want {
+					t.Errorf("\ngot:\n%s\nwant:\n%s", ans, test.want)
+				}
+			})
+	}
+}
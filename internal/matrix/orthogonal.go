@@ -0,0 +1,144 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// vector is dependent if its part orthogonal to the previous vectors
+// is smaller than this part of its length
+const dependenceTolerance = 1e-10
+
+type OrthogonalBasis struct {
+	// orthogonal vectors before normalization
+	Orthogonal  [][]float64
+	Orthonormal [][]float64
+	// indices of vectors that are linear combinations of the previous ones
+	Dependent []int
+}
+
+func dot(a, b []float64) float64 {
+	sum := 0.0
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
+
+func norm(v []float64) float64 {
+	return math.Sqrt(dot(v, v))
+}
+
+// returns columns of the matrix or its rows as vectors
+func (m *Matrix) vectors(byRows bool) [][]float64 {
+	if !byRows {
+		return transpose(m.Data)
+	}
+
+	vectors := make([][]float64, m.Rows)
+	for i, row := range m.Data {
+		vectors[i] = slices.Clone(row)
+	}
+	return vectors
+}
+
+// places vectors back as columns or rows of a matrix
+func arrange(vectors [][]float64, byRows bool) [][]float64 {
+	if len(vectors) == 0 {
+		return nil
+	}
+
+	if byRows {
+		return vectors
+	}
+	return transpose(vectors)
+}
+
+// orthogonalizes vectors in order. classical process subtracts projections
+// of the original vector, modified process subtracts them from the current
+// remainder, which is more stable in floating point
+func gramSchmidt(vectors [][]float64, modified bool) *OrthogonalBasis {
+	basis := &OrthogonalBasis{}
+
+	for i, v := range vectors {
+		u := slices.Clone(v)
+		for _, q := range basis.Orthonormal {
+			coefficient := dot(q, v)
+			if modified {
+				coefficient = dot(q, u)
+			}
+			for k := range u {
+				u[k] -= coefficient * q[k]
+			}
+		}
+
+		length := norm(u)
+		if length == 0 || length <= dependenceTolerance*norm(v) {
+			basis.Dependent = append(basis.Dependent, i)
+			continue
+		}
+
+		q := make([]float64, len(u))
+		for k := range u {
+			q[k] = u[k] / length
+		}
+
+		basis.Orthogonal = append(basis.Orthogonal, u)
+		basis.Orthonormal = append(basis.Orthonormal, q)
+	}
+
+	return basis
+}
+
+// orthogonalizes columns (or rows) of the matrix. vectors in the result
+// are placed the same way, dependent vectors are skipped
+func (m *Matrix) GramSchmidt(byRows, modified bool) *OrthogonalBasis {
+	basis := gramSchmidt(m.vectors(byRows), modified)
+	basis.Orthogonal = arrange(basis.Orthogonal, byRows)
+	basis.Orthonormal = arrange(basis.Orthonormal, byRows)
+	return basis
+}
+
+// returns orthogonal projection of the vector onto the span of columns (or rows)
+func (m *Matrix) Project(vector []float64, byRows bool) ([]float64, error) {
+	vectors := m.vectors(byRows)
+	if len(vector) != len(vectors[0]) {
+		return nil, fmt.Errorf("vector should have %d values, got %d", len(vectors[0]), len(vector))
+	}
+
+	projection := make([]float64, len(vector))
+	for _, q := range gramSchmidt(vectors, true).Orthonormal {
+		coefficient := dot(q, vector)
+		for k := range projection {
+			projection[k] += coefficient * q[k]
+		}
+	}
+
+	return projection, nil
+}
+
+// returns orthonormal basis of the orthogonal complement to the span
+// of columns (or rows), placed the same way. returns nil if the span
+// is the whole space
+func (m *Matrix) OrthogonalComplement(byRows bool) [][]float64 {
+	vectors := m.vectors(byRows)
+	count, n := len(vectors), len(vectors[0])
+
+	// standard basis vectors fill the space left after the span
+	for i := range n {
+		e := make([]float64, n)
+		e[i] = 1
+		vectors = append(vectors, e)
+	}
+
+	basis := gramSchmidt(vectors, true)
+	rank := count
+	for _, i := range basis.Dependent {
+		if i < count {
+			rank--
+		}
+	}
+
+	return arrange(basis.Orthonormal[rank:], byRows)
+}
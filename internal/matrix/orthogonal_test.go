@@ -0,0 +1,107 @@
+package matrix
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGramSchmidt(t *testing.T) {
+	tests := []struct {
+		name           string
+		matrix         [][]float64
+		byRows         bool
+		wantOrthogonal [][]float64
+		wantDependent  []int
+	}{
+		{"rows", [][]float64{{1, 1, 0}, {1, 0, 1}}, true, [][]float64{{1, 1, 0}, {0.5, -0.5, 1}}, nil},
+		{"columns", [][]float64{{1, 1}, {1, 0}, {0, 1}}, false, [][]float64{{1, 0.5}, {1, -0.5}, {0, 1}}, nil},
+		{"dependent", [][]float64{{1, 2, 0}, {2, 4, 0}, {0, 0, 3}}, true, [][]float64{{1, 2, 0}, {0, 0, 3}}, []int{1}},
+		{"zero", [][]float64{{0, 0}, {0, 1}}, true, [][]float64{{0, 1}}, []int{0}},
+	}
+
+	for _, test := range tests {
+		for _, modified := range []bool{false, true} {
+			name := test.name
+			if modified {
+				name += " modified"
+			}
+
+			t.Run(name,
+				func(t *testing.T) {
+					matrix, _ := NewMatrix(test.matrix, false)
+					basis := matrix.GramSchmidt(test.byRows, modified)
+
+					got := MatrixToString(roundMatrix(basis.Orthogonal), " ")
+					want := MatrixToString(test.wantOrthogonal, " ")
+					if got != want {
+						t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+					}
+
+					if !slices.Equal(basis.Dependent, test.wantDependent) {
+						t.Errorf("got dependent %v, want %v", basis.Dependent, test.wantDependent)
+					}
+				})
+		}
+	}
+}
+
+func TestGramSchmidtOrthonormal(t *testing.T) {
+	matrix, _ := NewMatrix(matrix6, false)
+	basis := matrix.GramSchmidt(false, true)
+
+	if !slices.Equal(basis.Dependent, []int{2}) {
+		t.Errorf("got dependent %v, want [2]", basis.Dependent)
+	}
+
+	q, _ := NewMatrix(basis.Orthonormal, false)
+	product := multiply(q.GetTranspose(), q.Data)
+	got := MatrixToString(roundMatrix(product), " ")
+	want := MatrixToString([][]float64{{1, 0}, {0, 1}}, " ")
+	if got != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestProject(t *testing.T) {
+	matrix, _ := NewMatrix([][]float64{{1, 0, 0}, {1, 1, 0}}, false)
+
+	ans, err := matrix.Project([]float64{1, 2, 3}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ArrayToString(roundMatrix([][]float64{ans})[0], " "); got != "1 2 0" {
+		t.Errorf("got %s, want 1 2 0", got)
+	}
+
+	if _, err := matrix.Project([]float64{1, 2}, true); err == nil {
+		t.Error("expected error for wrong vector length")
+	}
+}
+
+func TestOrthogonalComplement(t *testing.T) {
+	tests := []struct {
+		name   string
+		matrix [][]float64
+		byRows bool
+		want   [][]float64
+	}{
+		{"plane", [][]float64{{1, 0, 0}, {0, 1, 0}}, true, [][]float64{{0, 0, 1}}},
+		{"line", [][]float64{{0}, {0}, {2}}, false, [][]float64{{1, 0}, {0, 1}, {0, 0}}},
+		{"dependent", [][]float64{{1, 0}, {2, 0}}, true, [][]float64{{0, 1}}},
+		{"whole space", matrix9, true, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				matrix, _ := NewMatrix(test.matrix, false)
+				ans := matrix.OrthogonalComplement(test.byRows)
+
+				got := MatrixToString(roundMatrix(ans), " ")
+				want := MatrixToString(test.want, " ")
+				if got != want {
+					t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+				}
+			})
+	}
+}
@@ -0,0 +1,201 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"slices"
+)
+
+// ryser's formula goes through 2^n subsets of columns
+const maxPermanentSize = 30
+
+// returns matrix without the column of free terms
+func (m *Matrix) coefficients() [][]float64 {
+	if m.Augmented {
+		return deleteCol(m.Data, m.Cols-1)
+	}
+	return m.Data
+}
+
+// calculates permanent with Ryser's formula:
+//
+//	perm(A) = (-1)^n * sum over column subsets S of (-1)^|S| * prod_i sum_{j in S} a_ij
+//
+// subsets are enumerated in Gray code order, so every step adds
+// or removes one column and row sums are updated in O(n)
+func (m *Matrix) Permanent() (float64, error) {
+	if !m.Square {
+		return 0, errors.New("matrix is not a square")
+	}
+
+	if m.Rows > maxPermanentSize {
+		return 0, fmt.Errorf("permanent of order %d is too large, maximum is %d", m.Rows, maxPermanentSize)
+	}
+
+	return permanent(m.coefficients()), nil
+}
+
+func permanent[number Number](matrix [][]number) number {
+	n := len(matrix)
+
+	sums := make([]number, n)
+	total := number(0)
+
+	gray := uint64(0)
+	for k := uint64(1); k < 1<<n; k++ {
+		next := k ^ (k >> 1)
+		col := bits.TrailingZeros64(next ^ gray)
+		gray = next
+
+		if gray&(1<<col) != 0 {
+			for i := range n {
+				sums[i] += matrix[i][col]
+			}
+		} else {
+			for i := range n {
+				sums[i] -= matrix[i][col]
+			}
+		}
+
+		product := number(1)
+		for _, sum := range sums {
+			product *= sum
+		}
+
+		if (n-bits.OnesCount64(gray))%2 == 0 {
+			total += product
+		} else {
+			total -= product
+		}
+	}
+
+	return total
+}
+
+// calculates pfaffian of skew-symmetric matrix, pf(A)^2 = det(A).
+// uses Parlett-Reid elimination A = L T L^T with pivoting,
+// the same way as pfaffian_LTL in pfapack
+func (m *Matrix) Pfaffian() (float64, error) {
+	if m.Augmented || m.Rows != m.Cols {
+		return 0, errors.New("matrix is not a square")
+	}
+
+	for i := range m.Rows {
+		for j := i; j < m.Cols; j++ {
+			if m.Data[i][j] != -m.Data[j][i] {
+				return 0, fmt.Errorf("matrix is not skew-symmetric at (%d, %d)", i, j)
+			}
+		}
+	}
+
+	n := m.Rows
+	if n%2 != 0 {
+		return 0, nil
+	}
+
+	a := m.Clone().Data
+	pfaffian := 1.0
+
+	for k := 0; k < n-1; k += 2 {
+		pivot := k + 1
+		for i := k + 2; i < n; i++ {
+			if math.Abs(a[i][k]) > math.Abs(a[pivot][k]) {
+				pivot = i
+			}
+		}
+
+		// simultaneous swap of rows and columns keeps the matrix
+		// skew-symmetric and changes the sign of pfaffian
+		if pivot != k+1 {
+			a[k+1], a[pivot] = a[pivot], a[k+1]
+			for i := range n {
+				a[i][k+1], a[i][pivot] = a[i][pivot], a[i][k+1]
+			}
+			pfaffian = -pfaffian
+		}
+
+		if a[k+1][k] == 0 {
+			return 0, nil
+		}
+
+		pfaffian *= a[k][k+1]
+
+		tau := make([]float64, n)
+		for i := k + 2; i < n; i++ {
+			tau[i] = a[k][i] / a[k][k+1]
+		}
+
+		for i := k + 2; i < n; i++ {
+			for j := k + 2; j < n; j++ {
+				a[i][j] += tau[i]*a[j][k+1] - a[i][k+1]*tau[j]
+			}
+		}
+	}
+
+	return pfaffian, nil
+}
+
+// calculates minor of any order: determinant of the submatrix
+// on the intersection of given rows and columns
+func (m *Matrix) Minor(rows, cols []int) (float64, error) {
+	if len(rows) != len(cols) {
+		return 0, fmt.Errorf("minor needs the same number of rows and columns, got %d and %d", len(rows), len(cols))
+	}
+
+	if len(rows) == 0 {
+		return 0, errors.New("minor needs at least one row and column")
+	}
+
+	rows, cols = slices.Clone(rows), slices.Clone(cols)
+	slices.Sort(rows)
+	slices.Sort(cols)
+
+	for i := range rows {
+		if err := m.checkRow(rows[i]); err != nil {
+			return 0, err
+		}
+		if err := m.checkCol(cols[i]); err != nil {
+			return 0, err
+		}
+		if i > 0 && (rows[i] == rows[i-1] || cols[i] == cols[i-1]) {
+			return 0, errors.New("minor indices are repeated")
+		}
+	}
+
+	submatrix, memory, _ := Malloc[float64](len(rows), len(cols))
+	for i, row := range rows {
+		submatrix[i] = memory[i*len(cols) : (i+1)*len(cols)]
+		for j, col := range cols {
+			submatrix[i][j] = m.Data[row][col]
+		}
+	}
+
+	return calcDet(submatrix), nil
+}
+
+// calculates algebraic complement of the element (i, j)
+func (m *Matrix) Cofactor(i, j int) (float64, error) {
+	if !m.Square {
+		return 0, errors.New("matrix is not a square")
+	}
+
+	if err := m.checkRow(i); err != nil {
+		return 0, err
+	}
+	if j < 0 || j >= m.Rows {
+		return 0, fmt.Errorf("column index %d is out of range [0, %d)", j, m.Rows)
+	}
+
+	return cofactor(m.coefficients(), i, j), nil
+}
+
+// returns matrix of cofactors, the adjugate is its transpose
+func (m *Matrix) CofactorMatrix() [][]float64 {
+	if !m.Square {
+		return nil
+	}
+
+	return adjugate(m.coefficients())
+}
@@ -0,0 +1,135 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPermanent(t *testing.T) {
+	tests := []struct {
+		name      string
+		matrix    [][]float64
+		augmented bool
+		want      float64
+		wantErr   bool
+	}{
+		{"one", matrix9, false, 1, false},
+		{"square", matrix6, false, 450, false},
+		{"ones", [][]float64{{1, 1, 1, 1}, {1, 1, 1, 1}, {1, 1, 1, 1}, {1, 1, 1, 1}}, false, 24, false},
+		{"permutation", [][]float64{{0, 1, 0}, {0, 0, 1}, {1, 0, 0}}, false, 1, false},
+		{"augmented", [][]float64{{1, 2, 5}, {3, 4, 6}}, true, 10, false},
+		{"not square", matrix5, false, 0, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				matrix, _ := NewMatrix(test.matrix, test.augmented)
+				ans, err := matrix.Permanent()
+				if (err != nil) != test.wantErr {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if ans != test.want {
+					t.Errorf("got %v, want %v", ans, test.want)
+				}
+			})
+	}
+}
+
+func TestPfaffian(t *testing.T) {
+	tests := []struct {
+		name    string
+		matrix  [][]float64
+		want    float64
+		wantErr bool
+	}{
+		{"2x2", [][]float64{{0, 3}, {-3, 0}}, 3, false},
+		{"4x4", [][]float64{
+			{0, 1, 2, 3},
+			{-1, 0, 4, 5},
+			{-2, -4, 0, 6},
+			{-3, -5, -6, 0},
+		}, 8, false},
+		{"pivoting", [][]float64{
+			{0, 0, 2, 0},
+			{0, 0, 0, 3},
+			{-2, 0, 0, 5},
+			{0, -3, -5, 0},
+		}, -6, false},
+		{"odd", [][]float64{{0, 1, 2}, {-1, 0, 3}, {-2, -3, 0}}, 0, false},
+		{"not skew-symmetric", matrix6, 0, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				matrix, _ := NewMatrix(test.matrix, false)
+				ans, err := matrix.Pfaffian()
+				if (err != nil) != test.wantErr {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if math.Abs(ans-test.want) > 1e-9 {
+					t.Errorf("got %v, want %v", ans, test.want)
+				}
+			})
+	}
+}
+
+func TestMinor(t *testing.T) {
+	matrix, _ := NewMatrix(matrix6, false)
+
+	tests := []struct {
+		name    string
+		rows    []int
+		cols    []int
+		want    float64
+		wantErr bool
+	}{
+		{"element", []int{1}, []int{2}, 6, false},
+		{"corners", []int{0, 2}, []int{0, 2}, -12, false},
+		{"unsorted", []int{2, 0}, []int{2, 0}, -12, false},
+		{"full", []int{0, 1, 2}, []int{0, 1, 2}, 0, false},
+		{"mismatch", []int{0, 1}, []int{0}, 0, true},
+		{"repeated", []int{0, 0}, []int{0, 1}, 0, true},
+		{"out of range", []int{3}, []int{0}, 0, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				ans, err := matrix.Minor(test.rows, test.cols)
+				if (err != nil) != test.wantErr {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if ans != test.want {
+					t.Errorf("got %v, want %v", ans, test.want)
+				}
+			})
+	}
+}
+
+func TestCofactor(t *testing.T) {
+	matrix, _ := NewMatrix(matrix6, false)
+
+	if ans, _ := matrix.Cofactor(0, 0); ans != -3 {
+		t.Errorf("got %v, want -3", ans)
+	}
+
+	if ans, _ := matrix.Cofactor(0, 1); ans != 6 {
+		t.Errorf("got %v, want 6", ans)
+	}
+
+	if _, err := matrix.Cofactor(0, 3); err == nil {
+		t.Error("expected error for column out of range")
+	}
+
+	want := "-3 6 -3\n6 -12 6\n-3 6 -3\n"
+	if ans := MatrixToString(matrix.CofactorMatrix(), " "); ans != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", ans, want)
+	}
+
+	single, _ := NewMatrix(matrix9, false)
+	if ans := MatrixToString(single.CofactorMatrix(), " "); ans != "1\n" {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", ans, "1\n")
+	}
+}
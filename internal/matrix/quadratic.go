@@ -0,0 +1,383 @@
+package matrix
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// coefficients smaller than this part of the largest value
+// in the matrix are treated as zero
+const quadraticTolerance = 1e-10
+
+// quadratic form with diagonal matrix after the change of variables x = T y
+type CanonicalForm struct {
+	Coefficients []float64
+	// columns are coordinates of the new basis
+	Transformation [][]float64
+
+	tolerance float64
+}
+
+type Definiteness int
+
+const (
+	PositiveDefinite Definiteness = iota
+	NegativeDefinite
+	PositiveSemidefinite
+	NegativeSemidefinite
+	Indefinite
+)
+
+func (d Definiteness) String() string {
+	switch d {
+	case PositiveDefinite:
+		return "positive definite"
+	case NegativeDefinite:
+		return "negative definite"
+	case PositiveSemidefinite:
+		return "positive semidefinite"
+	case NegativeSemidefinite:
+		return "negative semidefinite"
+	default:
+		return "indefinite"
+	}
+}
+
+// returns error if the matrix is not symmetric, or tolerance for zero otherwise
+func (m *Matrix) checkSymmetric() (float64, error) {
+	if m.Augmented || m.Rows != m.Cols {
+		return 0, errors.New("matrix is not a square")
+	}
+
+	scale := 0.0
+	for i := range m.Rows {
+		for j := range m.Cols {
+			if m.Data[i][j] != m.Data[j][i] {
+				return 0, fmt.Errorf("matrix is not symmetric at (%d, %d)", i+1, j+1)
+			}
+			scale = max(scale, math.Abs(m.Data[i][j]))
+		}
+	}
+
+	return quadraticTolerance * max(scale, 1), nil
+}
+
+func identity(n int) [][]float64 {
+	matrix, memory, _ := Malloc[float64](n, n)
+	for i := range n {
+		matrix[i] = memory[i*n : (i+1)*n]
+		matrix[i][i] = 1
+	}
+	return matrix
+}
+
+// reduces the form with Lagrange method: squares are completed one variable
+// at a time with symmetric elimination. if there is no square to complete,
+// a variable is replaced with the sum of itself and a variable of a product
+func (m *Matrix) Lagrange() (*CanonicalForm, error) {
+	tolerance, err := m.checkSymmetric()
+	if err != nil {
+		return nil, err
+	}
+
+	n := m.Rows
+	a := m.Clone().Data
+	t := identity(n)
+
+	// the same operation on rows and columns of a and on columns of t
+	addCol := func(dst, src int, factor float64) {
+		for i := range n {
+			a[i][dst] += factor * a[i][src]
+			t[i][dst] += factor * t[i][src]
+		}
+		for j := range n {
+			a[dst][j] += factor * a[src][j]
+		}
+	}
+	swap := func(i, j int) {
+		a[i], a[j] = a[j], a[i]
+		for k := range n {
+			a[k][i], a[k][j] = a[k][j], a[k][i]
+			t[k][i], t[k][j] = t[k][j], t[k][i]
+		}
+	}
+
+	for k := range n {
+		if math.Abs(a[k][k]) <= tolerance {
+			square := -1
+			for j := k + 1; j < n; j++ {
+				if math.Abs(a[j][j]) > tolerance {
+					square = j
+					break
+				}
+			}
+
+			if square != -1 {
+				swap(k, square)
+			} else {
+				for j := k + 1; j < n; j++ {
+					if math.Abs(a[k][j]) > tolerance {
+						addCol(k, j, 1)
+						break
+					}
+				}
+			}
+		}
+
+		if math.Abs(a[k][k]) <= tolerance {
+			continue
+		}
+
+		for i := k + 1; i < n; i++ {
+			if a[i][k] != 0 {
+				addCol(i, k, -a[i][k]/a[k][k])
+			}
+		}
+	}
+
+	form := &CanonicalForm{Coefficients: make([]float64, n), Transformation: t, tolerance: tolerance}
+	for i := range n {
+		form.Coefficients[i] = a[i][i]
+	}
+
+	return form, nil
+}
+
+// finds eigenvalues and orthonormal eigenvectors with cyclic Jacobi rotations
+func jacobi(matrix [][]float64, tolerance float64) ([]float64, [][]float64) {
+	n := len(matrix)
+	a := make([][]float64, n)
+	for i, row := range matrix {
+		a[i] = slices.Clone(row)
+	}
+	v := identity(n)
+
+	for range 100 {
+		off := 0.0
+		for i := range n {
+			for j := i + 1; j < n; j++ {
+				off = max(off, math.Abs(a[i][j]))
+			}
+		}
+
+		if off <= tolerance*1e-3 {
+			break
+		}
+
+		for p := range n {
+			for q := p + 1; q < n; q++ {
+				if a[p][q] == 0 {
+					continue
+				}
+
+				theta := (a[q][q] - a[p][p]) / (2 * a[p][q])
+				tan := math.Copysign(1, theta) / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				cos := 1 / math.Sqrt(tan*tan+1)
+				sin := tan * cos
+
+				for k := range n {
+					akp, akq := a[k][p], a[k][q]
+					a[k][p] = cos*akp - sin*akq
+					a[k][q] = sin*akp + cos*akq
+				}
+				for k := range n {
+					apk, aqk := a[p][k], a[q][k]
+					a[p][k] = cos*apk - sin*aqk
+					a[q][k] = sin*apk + cos*aqk
+				}
+				for k := range n {
+					vkp, vkq := v[k][p], v[k][q]
+					v[k][p] = cos*vkp - sin*vkq
+					v[k][q] = sin*vkp + cos*vkq
+				}
+			}
+		}
+	}
+
+	values := make([]float64, n)
+	for i := range n {
+		values[i] = a[i][i]
+	}
+
+	return values, v
+}
+
+// reduces the form with orthogonal transformation: coefficients are
+// eigenvalues in descending order and the transformation has
+// orthonormal eigenvectors in columns
+func (m *Matrix) OrthogonalReduction() (*CanonicalForm, error) {
+	tolerance, err := m.checkSymmetric()
+	if err != nil {
+		return nil, err
+	}
+
+	values, vectors := jacobi(m.Data, tolerance)
+
+	n := m.Rows
+	order := make([]int, n)
+	for i := range n {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(i, j int) int {
+		return cmp.Compare(values[j], values[i])
+	})
+
+	form := &CanonicalForm{Coefficients: make([]float64, n), Transformation: identity(n), tolerance: tolerance}
+	for j, k := range order {
+		form.Coefficients[j] = values[k]
+		if math.Abs(values[k]) <= tolerance {
+			form.Coefficients[j] = 0
+		}
+		for i := range n {
+			form.Transformation[i][j] = vectors[i][k]
+		}
+	}
+
+	return form, nil
+}
+
+// returns numbers of positive, negative and zero coefficients
+func (f *CanonicalForm) Signature() (int, int, int) {
+	positive, negative, zero := 0, 0, 0
+	for _, c := range f.Coefficients {
+		switch {
+		case c > f.tolerance:
+			positive++
+		case c < -f.tolerance:
+			negative++
+		default:
+			zero++
+		}
+	}
+	return positive, negative, zero
+}
+
+// writes term of a linear combination like " - 2y1", 1 is not written
+func writeTerm(sb *strings.Builder, first bool, c float64, name string) {
+	switch {
+	case first && c < 0:
+		sb.WriteString("-")
+	case !first && c < 0:
+		sb.WriteString(" - ")
+	case !first:
+		sb.WriteString(" + ")
+	}
+
+	if math.Abs(c) != 1 {
+		sb.WriteString(FormatNumber(math.Abs(c)))
+	}
+	sb.WriteString(name)
+}
+
+// writes a sum like 2y1^2 - y2^2
+func formatSquares(coefficients []float64, tolerance float64) string {
+	var sb strings.Builder
+	for i, c := range coefficients {
+		if math.Abs(c) > tolerance {
+			writeTerm(&sb, sb.Len() == 0, c, fmt.Sprintf("y%d^2", i+1))
+		}
+	}
+
+	if sb.Len() == 0 {
+		return "0"
+	}
+	return sb.String()
+}
+
+// writes x = T y as a line per variable
+func formatTransformation(t [][]float64, tolerance float64) string {
+	var sb strings.Builder
+	for i, row := range t {
+		var line strings.Builder
+		for j, c := range row {
+			if math.Abs(c) > tolerance {
+				writeTerm(&line, line.Len() == 0, c, fmt.Sprintf("y%d", j+1))
+			}
+		}
+
+		if line.Len() == 0 {
+			line.WriteString("0")
+		}
+		fmt.Fprintf(&sb, "x%d = %s\n", i+1, line.String())
+	}
+	return sb.String()
+}
+
+func (f *CanonicalForm) String() string {
+	positive, negative, zero := f.Signature()
+	return fmt.Sprintf(
+		"q = %s\nsignature: (%d, %d, %d)\n%s",
+		formatSquares(f.Coefficients, f.tolerance),
+		positive, negative, zero,
+		formatTransformation(f.Transformation, f.tolerance),
+	)
+}
+
+// returns leading principal minors of the symmetric matrix
+func (m *Matrix) LeadingMinors() ([]float64, error) {
+	if _, err := m.checkSymmetric(); err != nil {
+		return nil, err
+	}
+
+	minors := make([]float64, m.Rows)
+	for k := range m.Rows {
+		leading := make([][]float64, k+1)
+		for i := range leading {
+			leading[i] = m.Data[i][:k+1]
+		}
+		minors[k] = calcDet(leading)
+	}
+
+	return minors, nil
+}
+
+// classifies the form with Sylvester's criterion. if some leading minor is
+// zero, the criterion does not decide and the signature of Lagrange
+// reduction is used instead. the zero form is positive semidefinite
+func (m *Matrix) Definiteness() (Definiteness, error) {
+	minors, err := m.LeadingMinors()
+	if err != nil {
+		return Indefinite, err
+	}
+
+	tolerance, _ := m.checkSymmetric()
+	scale := tolerance / quadraticTolerance
+
+	positive, alternating, degenerate := true, true, false
+	for k, minor := range minors {
+		// minor of order k+1 is a product of k+1 values
+		zero := quadraticTolerance * math.Pow(scale, float64(k+1))
+		if math.Abs(minor) <= zero {
+			degenerate = true
+			continue
+		}
+
+		positive = positive && minor > 0
+		alternating = alternating && (minor > 0) == (k%2 == 1)
+	}
+
+	switch {
+	case degenerate:
+	case positive:
+		return PositiveDefinite, nil
+	case alternating:
+		return NegativeDefinite, nil
+	default:
+		return Indefinite, nil
+	}
+
+	form, _ := m.Lagrange()
+	p, n, _ := form.Signature()
+	switch {
+	case n == 0:
+		return PositiveSemidefinite, nil
+	case p == 0:
+		return NegativeSemidefinite, nil
+	default:
+		return Indefinite, nil
+	}
+}
@@ -0,0 +1,108 @@
+package matrix
+
+import (
+	"testing"
+)
+
+func TestLagrange(t *testing.T) {
+	tests := []struct {
+		name   string
+		matrix [][]float64
+		want   string
+	}{
+		{"squares", [][]float64{{1, 1}, {1, 2}},
+			"q = y1^2 + y2^2\nsignature: (2, 0, 0)\nx1 = y1 - y2\nx2 = y2\n"},
+		{"product", [][]float64{{0, 1}, {1, 0}},
+			"q = 2y1^2 - 0.5y2^2\nsignature: (1, 1, 0)\nx1 = y1 - 0.5y2\nx2 = y1 + 0.5y2\n"},
+		{"swap", [][]float64{{0, 0}, {0, -3}},
+			"q = -3y1^2\nsignature: (0, 1, 1)\nx1 = y2\nx2 = y1\n"},
+		{"zero", [][]float64{{0}},
+			"q = 0\nsignature: (0, 0, 1)\nx1 = y1\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				matrix, _ := NewMatrix(test.matrix, false)
+				form, err := matrix.Lagrange()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if ans := form.String(); ans != test.want {
+					t.Errorf("\ngot:\n%s\nwant:\n%s", ans, test.want)
+				}
+			})
+	}
+}
+
+func TestOrthogonalReduction(t *testing.T) {
+	data := [][]float64{{2, 1, 0}, {1, 2, 0}, {0, 0, -1}}
+	matrix, _ := NewMatrix(data, false)
+
+	form, err := matrix.OrthogonalReduction()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := ArrayToString(roundMatrix([][]float64{form.Coefficients})[0], " ")
+	if got != "3 1 -1" {
+		t.Errorf("got coefficients %s, want 3 1 -1", got)
+	}
+
+	// Q^T A Q is diagonal with the coefficients, Q^T Q is identity
+	q, _ := NewMatrix(form.Transformation, false)
+	diagonal := multiply(q.GetTranspose(), multiply(data, q.Data))
+	want := [][]float64{{3, 0, 0}, {0, 1, 0}, {0, 0, -1}}
+	if got, want := MatrixToString(roundMatrix(diagonal), " "), MatrixToString(want, " "); got != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+
+	product := multiply(q.GetTranspose(), q.Data)
+	if got, want := MatrixToString(roundMatrix(product), " "), MatrixToString(identity(3), " "); got != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestDefiniteness(t *testing.T) {
+	tests := []struct {
+		name    string
+		matrix  [][]float64
+		want    Definiteness
+		wantErr bool
+	}{
+		{"positive", [][]float64{{2, 1}, {1, 2}}, PositiveDefinite, false},
+		{"negative", [][]float64{{-2, 0}, {0, -3}}, NegativeDefinite, false},
+		{"indefinite", [][]float64{{1, 0}, {0, -1}}, Indefinite, false},
+		{"positive semidefinite", [][]float64{{1, 1}, {1, 1}}, PositiveSemidefinite, false},
+		{"negative semidefinite", [][]float64{{-1, 0}, {0, 0}}, NegativeSemidefinite, false},
+		{"zero minor", [][]float64{{0, 1}, {1, 0}}, Indefinite, false},
+		{"not symmetric", matrix6, Indefinite, true},
+		{"not square", matrix5, Indefinite, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				matrix, _ := NewMatrix(test.matrix, false)
+				ans, err := matrix.Definiteness()
+				if (err != nil) != test.wantErr {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if ans != test.want {
+					t.Errorf("got %v, want %v", ans, test.want)
+				}
+			})
+	}
+}
+
+func TestLeadingMinors(t *testing.T) {
+	matrix, _ := NewMatrix([][]float64{{2, 1, 0}, {1, 2, 1}, {0, 1, 2}}, false)
+
+	minors, err := matrix.LeadingMinors()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ArrayToString(minors, " "); got != "2 3 4" {
+		t.Errorf("got %s, want 2 3 4", got)
+	}
+}
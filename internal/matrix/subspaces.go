@@ -0,0 +1,188 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+)
+
+// values smaller than this part of the largest value in the matrix
+// are treated as zero in the elimination
+const rankTolerance = 1e-10
+
+// reduces the matrix to the reduced row echelon form with partial pivoting.
+// returns the reduced matrix and indices of the pivot columns
+func rref(matrix [][]float64) ([][]float64, []int) {
+	rows, cols := len(matrix), len(matrix[0])
+
+	reduced, memory, _ := Malloc[float64](rows, cols)
+	scale := 0.0
+	for i, row := range matrix {
+		reduced[i] = memory[i*cols : (i+1)*cols]
+		copy(reduced[i], row)
+		for _, value := range row {
+			scale = max(scale, math.Abs(value))
+		}
+	}
+	tolerance := rankTolerance * max(scale, 1)
+
+	var pivots []int
+	for r, k := 0, 0; r < rows && k < cols; k++ {
+		pivot := r
+		for i := r + 1; i < rows; i++ {
+			if math.Abs(reduced[i][k]) > math.Abs(reduced[pivot][k]) {
+				pivot = i
+			}
+		}
+
+		if math.Abs(reduced[pivot][k]) < tolerance {
+			for i := r; i < rows; i++ {
+				reduced[i][k] = 0
+			}
+			continue
+		}
+
+		reduced[r], reduced[pivot] = reduced[pivot], reduced[r]
+
+		value := reduced[r][k]
+		for j := k; j < cols; j++ {
+			reduced[r][j] /= value
+		}
+
+		for i := range rows {
+			if i == r || reduced[i][k] == 0 {
+				continue
+			}
+
+			factor := reduced[i][k]
+			for j := k; j < cols; j++ {
+				reduced[i][j] -= factor * reduced[r][j]
+			}
+			reduced[i][k] = 0
+		}
+
+		pivots = append(pivots, k)
+		r++
+	}
+
+	return reduced, pivots
+}
+
+// returns the reduced row echelon form of the matrix
+func (m *Matrix) RREF() [][]float64 {
+	reduced, _ := rref(m.Data)
+	return reduced
+}
+
+func (m *Matrix) Rank() int {
+	_, pivots := rref(m.Data)
+	return len(pivots)
+}
+
+// returns basis of the null space as columns. returns nil
+// if the null space is trivial
+func nullSpace(matrix [][]float64) [][]float64 {
+	reduced, pivots := rref(matrix)
+	cols := len(matrix[0])
+	if len(pivots) == cols {
+		return nil
+	}
+
+	isPivot := make([]bool, cols)
+	for _, k := range pivots {
+		isPivot[k] = true
+	}
+
+	var basis [][]float64
+	for free := range cols {
+		if isPivot[free] {
+			continue
+		}
+
+		vector := make([]float64, cols)
+		vector[free] = 1
+		for i, k := range pivots {
+			// zero is not negated to avoid -0 in the result
+			if value := reduced[i][free]; value != 0 {
+				vector[k] = -value
+			}
+		}
+		basis = append(basis, vector)
+	}
+
+	return transpose(basis)
+}
+
+// returns basis of the solutions of A x = 0 as columns,
+// nil if there is only the zero solution
+func (m *Matrix) NullSpace() [][]float64 {
+	return nullSpace(m.Data)
+}
+
+// returns basis of the solutions of y A = 0 as rows,
+// nil if there is only the zero solution
+func (m *Matrix) LeftNullSpace() [][]float64 {
+	basis := nullSpace(transpose(m.Data))
+	if basis == nil {
+		return nil
+	}
+	return transpose(basis)
+}
+
+// returns pivot columns of the matrix as basis of its column space,
+// nil for the zero matrix
+func (m *Matrix) ColumnSpace() [][]float64 {
+	_, pivots := rref(m.Data)
+	if len(pivots) == 0 {
+		return nil
+	}
+
+	basis, memory, _ := Malloc[float64](m.Rows, len(pivots))
+	for i, row := range m.Data {
+		basis[i] = memory[i*len(pivots) : (i+1)*len(pivots)]
+		for j, k := range pivots {
+			basis[i][j] = row[k]
+		}
+	}
+
+	return basis
+}
+
+// returns nonzero rows of the reduced row echelon form as basis
+// of the row space, nil for the zero matrix
+func (m *Matrix) RowSpace() [][]float64 {
+	reduced, pivots := rref(m.Data)
+	if len(pivots) == 0 {
+		return nil
+	}
+	return reduced[:len(pivots)]
+}
+
+// reports whether columns (or rows) of the matrix are linearly independent
+func (m *Matrix) Independent(byRows bool) bool {
+	count := m.Cols
+	if byRows {
+		count = m.Rows
+	}
+	return m.Rank() == count
+}
+
+// returns transition matrix from the basis in columns of the matrix
+// to the basis in columns of the other one: coordinates of a vector
+// in the second basis are the product of the transition matrix
+// and its coordinates in the first basis
+func (m *Matrix) TransitionMatrix(to *Matrix) ([][]float64, error) {
+	if m.Rows != m.Cols || m.Rows != to.Rows || m.Cols != to.Cols {
+		return nil, dimensionError(m, to)
+	}
+
+	if !m.Independent(false) || !to.Independent(false) {
+		return nil, errors.New("basis vectors are linearly dependent")
+	}
+
+	// columns of the first basis expressed in the second one
+	x, err := to.SolveLeft(m)
+	if err != nil {
+		return nil, err
+	}
+	return x.Data, nil
+}
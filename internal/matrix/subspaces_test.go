@@ -0,0 +1,103 @@
+package matrix
+
+import (
+	"testing"
+)
+
+func TestSubspaces(t *testing.T) {
+	data := [][]float64{{1, 2, 1}, {2, 4, 3}, {3, 6, 4}}
+
+	tests := []struct {
+		name  string
+		space func(m *Matrix) [][]float64
+		want  [][]float64
+	}{
+		{"rref", (*Matrix).RREF, [][]float64{{1, 2, 0}, {0, 0, 1}, {0, 0, 0}}},
+		{"null space", (*Matrix).NullSpace, [][]float64{{-2}, {1}, {0}}},
+		{"column space", (*Matrix).ColumnSpace, [][]float64{{1, 1}, {2, 3}, {3, 4}}},
+		{"row space", (*Matrix).RowSpace, [][]float64{{1, 2, 0}, {0, 0, 1}}},
+		{"left null space", (*Matrix).LeftNullSpace, [][]float64{{-1, -1, 1}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				matrix, _ := NewMatrix(data, false)
+				got := MatrixToString(roundMatrix(test.space(matrix)), " ")
+				want := MatrixToString(test.want, " ")
+				if got != want {
+					t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+				}
+			})
+	}
+}
+
+func TestTrivialSubspaces(t *testing.T) {
+	identity, _ := NewMatrix([][]float64{{1, 0}, {0, 1}}, false)
+	if identity.NullSpace() != nil || identity.LeftNullSpace() != nil {
+		t.Error("null spaces of identity should be trivial")
+	}
+
+	zero, _ := NewMatrix(matrix7, false)
+	if zero.ColumnSpace() != nil || zero.RowSpace() != nil || zero.Rank() != 0 {
+		t.Error("spaces of zero matrix should be trivial")
+	}
+}
+
+func TestIndependent(t *testing.T) {
+	tests := []struct {
+		name   string
+		matrix [][]float64
+		byRows bool
+		want   bool
+	}{
+		{"dependent columns", matrix6, false, false},
+		{"dependent rows", matrix6, true, false},
+		{"columns", [][]float64{{1, 0}, {0, 1}, {1, 1}}, false, true},
+		{"rows", [][]float64{{1, 0}, {0, 1}, {1, 1}}, true, false},
+		{"zero", matrix7, false, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				matrix, _ := NewMatrix(test.matrix, false)
+				if ans := matrix.Independent(test.byRows); ans != test.want {
+					t.Errorf("got %v, want %v", ans, test.want)
+				}
+			})
+	}
+}
+
+func TestTransitionMatrix(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to [][]float64
+		want     [][]float64
+		wantErr  bool
+	}{
+		{"from standard", [][]float64{{1, 0}, {0, 1}}, [][]float64{{1, 1}, {0, 1}}, [][]float64{{1, -1}, {0, 1}}, false},
+		{"to standard", [][]float64{{1, 1}, {0, 1}}, [][]float64{{1, 0}, {0, 1}}, [][]float64{{1, 1}, {0, 1}}, false},
+		{"same", [][]float64{{2, 1}, {1, 1}}, [][]float64{{2, 1}, {1, 1}}, [][]float64{{1, 0}, {0, 1}}, false},
+		{"dependent", [][]float64{{1, 0}, {0, 1}}, [][]float64{{1, 2}, {2, 4}}, nil, true},
+		{"dimensions", [][]float64{{1, 0}, {0, 1}}, matrix6, nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				from, _ := NewMatrix(test.from, false)
+				to, _ := NewMatrix(test.to, false)
+				ans, err := from.TransitionMatrix(to)
+				if (err != nil) != test.wantErr {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				got := MatrixToString(roundMatrix(ans), " ")
+				want := MatrixToString(test.want, " ")
+				if got != want {
+					t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+				}
+			})
+	}
+}
@@ -0,0 +1,50 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+)
+
+// default tolerance for Verify, roots of Cramer's rule
+// usually have much smaller residuals
+const RootsTolerance = 1e-9
+
+type Verification struct {
+	// b_i - (a_i1*x_1 + ... + a_in*x_n) for every equation
+	Residuals []float64
+	// the largest absolute residual
+	Max float64
+	// false if Max exceeds the tolerance
+	Passed bool
+}
+
+// substitutes roots filled by Calculate back into the equations.
+// tolerance is relative to the largest absolute value in the matrix,
+// but is never smaller than the tolerance itself
+func (m *Matrix) Verify(tolerance float64) (*Verification, error) {
+	if !m.Augmented {
+		return nil, errors.New("matrix is not augmented")
+	}
+
+	roots := m.GetRoots()
+	if roots == nil {
+		return nil, errors.New("roots are not calculated")
+	}
+
+	v := &Verification{Residuals: make([]float64, m.Rows)}
+	scale := 1.0
+	for i, row := range m.Data {
+		residual := row[m.Cols-1]
+		scale = max(scale, math.Abs(residual))
+		for j, root := range roots {
+			residual -= row[j] * root
+			scale = max(scale, math.Abs(row[j]))
+		}
+
+		v.Residuals[i] = residual
+		v.Max = max(v.Max, math.Abs(residual))
+	}
+
+	v.Passed = v.Max <= tolerance*scale
+	return v, nil
+}
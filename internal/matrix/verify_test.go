@@ -0,0 +1,36 @@
+package matrix
+
+import (
+	"testing"
+)
+
+func TestVerify(t *testing.T) {
+	m, _ := NewMatrix([][]float64{{2, 1, 5}, {1, -1, 1}}, true)
+	if _, err := m.Verify(RootsTolerance); err == nil {
+		t.Error("expected error for roots that are not calculated")
+	}
+
+	m.Calculate()
+	v, err := m.Verify(RootsTolerance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !v.Passed || v.Max != 0 {
+		t.Errorf("got max residual %v, passed %v", v.Max, v.Passed)
+	}
+
+	// determinants of another system, so both roots are 1
+	m.Determinants = []float64{1, 1, 1}
+	v, _ = m.Verify(RootsTolerance)
+	if v.Passed {
+		t.Error("wrong roots passed verification")
+	}
+	if got := ArrayToString(v.Residuals, " "); got != "2 1" || v.Max != 2 {
+		t.Errorf("got residuals %s, max %v", got, v.Max)
+	}
+
+	square, _ := NewMatrix(matrix6, false)
+	if _, err := square.Verify(RootsTolerance); err == nil {
+		t.Error("expected error for not augmented matrix")
+	}
+}
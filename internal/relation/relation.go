@@ -0,0 +1,276 @@
+package relation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/shimeoki/mlat/internal/matrix"
+)
+
+type Property struct {
+	Holds bool
+	// pair of elements that breaks the property, empty if the property holds
+	Counterexample string
+}
+
+// properties of a binary relation on the set {1, ..., Size}.
+// elements in Classes and HasseEdges are counted from 0
+type Report struct {
+	Size int
+
+	Reflexive     Property
+	Irreflexive   Property
+	Symmetric     Property
+	Antisymmetric Property
+	Asymmetric    Property
+	Transitive    Property
+
+	Equivalence        bool
+	PartialOrder       bool
+	StrictPartialOrder bool
+	LinearOrder        bool
+
+	// equivalence classes, only for equivalence relations
+	Classes [][]int
+	// covering pairs (a, b): a < b and there is no c with a < c < b.
+	// only for partial and strict partial orders
+	HasseEdges [][2]int
+}
+
+func pair(a, b int) string {
+	return fmt.Sprintf("(%d, %d)", a+1, b+1)
+}
+
+// analyzes square 0/1 matrix of the relation: m[a][b] = 1 if a R b
+func Analyze(m *matrix.Matrix) (*Report, error) {
+	if m.Rows != m.Cols {
+		return nil, errors.New("matrix is not a square")
+	}
+
+	r, err := matrix.NewBoolMatrixFromData(m.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Size: r.Rows}
+	report.Reflexive = reflexive(r)
+	report.Irreflexive = irreflexive(r)
+	report.Symmetric = symmetric(r)
+	report.Antisymmetric = antisymmetric(r)
+	report.Asymmetric = asymmetric(r)
+	report.Transitive = transitive(r)
+
+	report.Equivalence = report.Reflexive.Holds && report.Symmetric.Holds && report.Transitive.Holds
+	report.PartialOrder = report.Reflexive.Holds && report.Antisymmetric.Holds && report.Transitive.Holds
+	report.StrictPartialOrder = report.Irreflexive.Holds && report.Transitive.Holds
+	report.LinearOrder = report.PartialOrder && total(r)
+
+	if report.Equivalence {
+		report.Classes = classes(r)
+	}
+
+	if report.PartialOrder || report.StrictPartialOrder {
+		report.HasseEdges = hasse(r)
+	}
+
+	return report, nil
+}
+
+func reflexive(r *matrix.BoolMatrix) Property {
+	for a := range r.Rows {
+		if !r.Get(a, a) {
+			return Property{false, pair(a, a) + " is not in R"}
+		}
+	}
+	return Property{Holds: true}
+}
+
+func irreflexive(r *matrix.BoolMatrix) Property {
+	for a := range r.Rows {
+		if r.Get(a, a) {
+			return Property{false, pair(a, a) + " is in R"}
+		}
+	}
+	return Property{Holds: true}
+}
+
+func symmetric(r *matrix.BoolMatrix) Property {
+	for a := range r.Rows {
+		for b := range r.Cols {
+			if r.Get(a, b) && !r.Get(b, a) {
+				return Property{false, pair(a, b) + " is in R, but " + pair(b, a) + " is not"}
+			}
+		}
+	}
+	return Property{Holds: true}
+}
+
+func antisymmetric(r *matrix.BoolMatrix) Property {
+	for a := range r.Rows {
+		for b := a + 1; b < r.Cols; b++ {
+			if r.Get(a, b) && r.Get(b, a) {
+				return Property{false, "both " + pair(a, b) + " and " + pair(b, a) + " are in R"}
+			}
+		}
+	}
+	return Property{Holds: true}
+}
+
+func asymmetric(r *matrix.BoolMatrix) Property {
+	for a := range r.Rows {
+		for b := a; b < r.Cols; b++ {
+			if r.Get(a, b) && r.Get(b, a) {
+				if a == b {
+					return Property{false, pair(a, a) + " is in R"}
+				}
+				return Property{false, "both " + pair(a, b) + " and " + pair(b, a) + " are in R"}
+			}
+		}
+	}
+	return Property{Holds: true}
+}
+
+func transitive(r *matrix.BoolMatrix) Property {
+	// R is transitive if R∘R is a subset of R
+	composition, _ := r.Product(r)
+	for a := range r.Rows {
+		for c := range r.Cols {
+			if !composition.Get(a, c) || r.Get(a, c) {
+				continue
+			}
+
+			for b := range r.Rows {
+				if r.Get(a, b) && r.Get(b, c) {
+					return Property{false, fmt.Sprintf(
+						"%s and %s are in R, but %s is not", pair(a, b), pair(b, c), pair(a, c),
+					)}
+				}
+			}
+		}
+	}
+	return Property{Holds: true}
+}
+
+func total(r *matrix.BoolMatrix) bool {
+	for a := range r.Rows {
+		for b := a + 1; b < r.Cols; b++ {
+			if !r.Get(a, b) && !r.Get(b, a) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func classes(r *matrix.BoolMatrix) [][]int {
+	var classes [][]int
+
+	assigned := make([]bool, r.Rows)
+	for a := range r.Rows {
+		if assigned[a] {
+			continue
+		}
+
+		var class []int
+		for b := range r.Cols {
+			if r.Get(a, b) {
+				class = append(class, b)
+				assigned[b] = true
+			}
+		}
+		classes = append(classes, class)
+	}
+
+	return classes
+}
+
+func hasse(r *matrix.BoolMatrix) [][2]int {
+	var edges [][2]int
+
+	for a := range r.Rows {
+		for b := range r.Cols {
+			if a == b || !r.Get(a, b) {
+				continue
+			}
+
+			covered := true
+			for c := range r.Rows {
+				if c != a && c != b && r.Get(a, c) && r.Get(c, b) {
+					covered = false
+					break
+				}
+			}
+
+			if covered {
+				edges = append(edges, [2]int{a, b})
+			}
+		}
+	}
+
+	return edges
+}
+
+func (r *Report) String() string {
+	var sb strings.Builder
+
+	properties := []struct {
+		name     string
+		property Property
+	}{
+		{"reflexive", r.Reflexive},
+		{"irreflexive", r.Irreflexive},
+		{"symmetric", r.Symmetric},
+		{"antisymmetric", r.Antisymmetric},
+		{"asymmetric", r.Asymmetric},
+		{"transitive", r.Transitive},
+	}
+
+	for _, p := range properties {
+		if p.property.Holds {
+			fmt.Fprintf(&sb, "%s: yes\n", p.name)
+		} else {
+			fmt.Fprintf(&sb, "%s: no, %s\n", p.name, p.property.Counterexample)
+		}
+	}
+
+	var kinds []string
+	if r.Equivalence {
+		kinds = append(kinds, "equivalence")
+	}
+	if r.LinearOrder {
+		kinds = append(kinds, "linear order")
+	} else if r.PartialOrder {
+		kinds = append(kinds, "partial order")
+	}
+	if r.StrictPartialOrder {
+		kinds = append(kinds, "strict partial order")
+	}
+	if len(kinds) == 0 {
+		kinds = append(kinds, "none")
+	}
+	fmt.Fprintf(&sb, "\nclassification: %s\n", strings.Join(kinds, ", "))
+
+	if r.Equivalence {
+		sb.WriteString("\nequivalence classes:\n")
+		for _, class := range r.Classes {
+			elements := make([]string, len(class))
+			for i, a := range class {
+				elements[i] = fmt.Sprint(a + 1)
+			}
+			fmt.Fprintf(&sb, "{%s}\n", strings.Join(elements, ", "))
+		}
+	}
+
+	if r.PartialOrder || r.StrictPartialOrder {
+		sb.WriteString("\nHasse diagram edges:\n")
+		if len(r.HasseEdges) == 0 {
+			sb.WriteString("none\n")
+		}
+		for _, edge := range r.HasseEdges {
+			fmt.Fprintf(&sb, "%d -> %d\n", edge[0]+1, edge[1]+1)
+		}
+	}
+
+	return sb.String()
+}
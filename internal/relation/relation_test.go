@@ -0,0 +1,140 @@
+package relation
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/shimeoki/mlat/internal/matrix"
+)
+
+func TestProperties(t *testing.T) {
+	tests := []struct {
+		name   string
+		matrix [][]float64
+		// reflexive, irreflexive, symmetric, antisymmetric, asymmetric, transitive
+		want [6]bool
+	}{
+		{"empty", [][]float64{{0, 0}, {0, 0}}, [6]bool{false, true, true, true, true, true}},
+		{"full", [][]float64{{1, 1}, {1, 1}}, [6]bool{true, false, true, false, false, true}},
+		{"identity", [][]float64{{1, 0}, {0, 1}}, [6]bool{true, false, true, true, false, true}},
+		{"less than", [][]float64{{0, 1, 1}, {0, 0, 1}, {0, 0, 0}}, [6]bool{false, true, false, true, true, true}},
+		{"chain", [][]float64{{0, 1, 0}, {0, 0, 1}, {0, 0, 0}}, [6]bool{false, true, false, true, true, false}},
+		{"cycle", [][]float64{{0, 1}, {1, 0}}, [6]bool{false, true, true, false, false, false}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				m, _ := matrix.NewMatrix(test.matrix, false)
+				r, err := Analyze(m)
+				if err != nil {
+					t.Fatal(err)
+				}
+				ans := [6]bool{
+					r.Reflexive.Holds, r.Irreflexive.Holds, r.Symmetric.Holds,
+					r.Antisymmetric.Holds, r.Asymmetric.Holds, r.Transitive.Holds,
+				}
+				if ans != test.want {
+					t.Errorf("got %v, want %v", ans, test.want)
+				}
+			})
+	}
+}
+
+func TestCounterexample(t *testing.T) {
+	m, _ := matrix.NewMatrix([][]float64{{0, 1, 0}, {0, 0, 1}, {0, 0, 0}}, false)
+	r, err := Analyze(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "(1, 2) and (2, 3) are in R, but (1, 3) is not"
+	if r.Transitive.Counterexample != want {
+		t.Errorf("got %q, want %q", r.Transitive.Counterexample, want)
+	}
+
+	want = "(1, 2) is in R, but (2, 1) is not"
+	if r.Symmetric.Counterexample != want {
+		t.Errorf("got %q, want %q", r.Symmetric.Counterexample, want)
+	}
+}
+
+func TestEquivalence(t *testing.T) {
+	// classes {1, 3} and {2}
+	m, _ := matrix.NewMatrix([][]float64{{1, 0, 1}, {0, 1, 0}, {1, 0, 1}}, false)
+	r, err := Analyze(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !r.Equivalence || r.PartialOrder {
+		t.Fatalf("wrong classification:\n%s", r)
+	}
+
+	want := [][]int{{0, 2}, {1}}
+	if !reflect.DeepEqual(r.Classes, want) {
+		t.Errorf("got %v, want %v", r.Classes, want)
+	}
+}
+
+func TestOrders(t *testing.T) {
+	tests := []struct {
+		name    string
+		matrix  [][]float64
+		partial bool
+		strict  bool
+		linear  bool
+		edges   [][2]int
+	}{
+		// divisibility on {1, 2, 3, 6}
+		{"divisibility", [][]float64{
+			{1, 1, 1, 1},
+			{0, 1, 0, 1},
+			{0, 0, 1, 1},
+			{0, 0, 0, 1},
+		}, true, false, false, [][2]int{{0, 1}, {0, 2}, {1, 3}, {2, 3}}},
+		{"less or equal", [][]float64{
+			{1, 1, 1},
+			{0, 1, 1},
+			{0, 0, 1},
+		}, true, false, true, [][2]int{{0, 1}, {1, 2}}},
+		{"less than", [][]float64{
+			{0, 1, 1},
+			{0, 0, 1},
+			{0, 0, 0},
+		}, false, true, false, [][2]int{{0, 1}, {1, 2}}},
+		{"not an order", [][]float64{
+			{1, 1},
+			{1, 1},
+		}, false, false, false, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				m, _ := matrix.NewMatrix(test.matrix, false)
+				r, err := Analyze(m)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if r.PartialOrder != test.partial || r.StrictPartialOrder != test.strict || r.LinearOrder != test.linear {
+					t.Fatalf("wrong classification:\n%s", r)
+				}
+				if !reflect.DeepEqual(r.HasseEdges, test.edges) {
+					t.Errorf("got %v, want %v", r.HasseEdges, test.edges)
+				}
+			})
+	}
+}
+
+func TestAnalyzeErrors(t *testing.T) {
+	m, _ := matrix.NewMatrix([][]float64{{1, 0, 1}}, false)
+	if _, err := Analyze(m); err == nil {
+		t.Error("expected error for non-square matrix")
+	}
+
+	m, _ = matrix.NewMatrix([][]float64{{1, 2}, {0, 1}}, false)
+	if _, err := Analyze(m); err == nil {
+		t.Error("expected error for non-boolean matrix")
+	}
+}
@@ -0,0 +1,342 @@
+package symbolic
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+	"strings"
+)
+
+type Solutions int
+
+const (
+	NoSolutions Solutions = iota
+	UniqueSolution
+	InfinitelyMany
+	// some cell has zero denominator
+	Undefined
+)
+
+func (s Solutions) String() string {
+	switch s {
+	case NoSolutions:
+		return "no solutions"
+	case UniqueSolution:
+		return "unique solution"
+	case InfinitelyMany:
+		return "infinitely many solutions"
+	default:
+		return "system is undefined"
+	}
+}
+
+// parameter value where the system may change its kind
+type CriticalValue struct {
+	// nil if the value is irrational, then Approx is a numeric root
+	Value     *big.Rat
+	Approx    float64
+	Solutions Solutions
+}
+
+type Analysis struct {
+	// empty if the system has no variables
+	Parameter string
+	// main determinant, nil if the matrix of coefficients is not a square
+	Determinant *Rational
+	// kind of the system for all values except the critical ones
+	Generic  Solutions
+	Critical []CriticalValue
+}
+
+func classify(rankA, rankAb, unknowns int) Solutions {
+	switch {
+	case rankA < rankAb:
+		return NoSolutions
+	case rankA == unknowns:
+		return UniqueSolution
+	default:
+		return InfinitelyMany
+	}
+}
+
+// gaussian elimination over rational functions.
+// returns pivots and their columns
+func eliminate(matrix [][]*Rational) ([]*Rational, []int) {
+	a := make([][]*Rational, len(matrix))
+	for i, row := range matrix {
+		a[i] = slices.Clone(row)
+	}
+
+	var pivots []*Rational
+	var cols []int
+
+	rows := len(a)
+	for r, k := 0, 0; r < rows && k < len(a[0]); k++ {
+		pivot := -1
+		for i := r; i < rows; i++ {
+			if !a[i][k].IsZero() {
+				pivot = i
+				break
+			}
+		}
+
+		if pivot == -1 {
+			continue
+		}
+
+		a[r], a[pivot] = a[pivot], a[r]
+		for i := r + 1; i < rows; i++ {
+			if a[i][k].IsZero() {
+				continue
+			}
+
+			factor, _ := a[i][k].Div(a[r][k])
+			for j := k; j < len(a[i]); j++ {
+				a[i][j] = a[i][j].Sub(factor.Mul(a[r][j]))
+			}
+		}
+
+		pivots = append(pivots, a[r][k])
+		cols = append(cols, k)
+		r++
+	}
+
+	return pivots, cols
+}
+
+// ranks of the coefficients and of the whole augmented matrix
+// with exact elimination over rationals
+func ranks(matrix [][]*big.Rat) (int, int) {
+	rows, cols := len(matrix), len(matrix[0])
+
+	rankA, rankAb := 0, 0
+	for r, k := 0, 0; r < rows && k < cols; k++ {
+		pivot := -1
+		for i := r; i < rows; i++ {
+			if matrix[i][k].Sign() != 0 {
+				pivot = i
+				break
+			}
+		}
+
+		if pivot == -1 {
+			continue
+		}
+
+		matrix[r], matrix[pivot] = matrix[pivot], matrix[r]
+		for i := r + 1; i < rows; i++ {
+			factor := new(big.Rat).Quo(matrix[i][k], matrix[r][k])
+			for j := k; j < cols; j++ {
+				matrix[i][j].Sub(matrix[i][j], new(big.Rat).Mul(factor, matrix[r][j]))
+			}
+		}
+
+		if k < cols-1 {
+			rankA++
+		}
+		rankAb++
+		r++
+	}
+
+	return rankA, rankAb
+}
+
+// the same as ranks, values smaller than tolerance are treated as zero
+func ranksFloat(matrix [][]float64) (int, int) {
+	rows, cols := len(matrix), len(matrix[0])
+
+	scale := 0.0
+	for _, row := range matrix {
+		for _, value := range row {
+			scale = max(scale, math.Abs(value))
+		}
+	}
+	tolerance := 1e-9 * max(scale, 1)
+
+	rankA, rankAb := 0, 0
+	for r, k := 0, 0; r < rows && k < cols; k++ {
+		pivot := r
+		for i := r + 1; i < rows; i++ {
+			if math.Abs(matrix[i][k]) > math.Abs(matrix[pivot][k]) {
+				pivot = i
+			}
+		}
+
+		if math.Abs(matrix[pivot][k]) < tolerance {
+			continue
+		}
+
+		matrix[r], matrix[pivot] = matrix[pivot], matrix[r]
+		for i := r + 1; i < rows; i++ {
+			factor := matrix[i][k] / matrix[r][k]
+			for j := k; j < cols; j++ {
+				matrix[i][j] -= factor * matrix[r][j]
+			}
+		}
+
+		if k < cols-1 {
+			rankA++
+		}
+		rankAb++
+		r++
+	}
+
+	return rankA, rankAb
+}
+
+func (m *Matrix) solutionsAt(name string, value *big.Rat) Solutions {
+	values := make([][]*big.Rat, m.Rows)
+	for i, row := range m.Data {
+		values[i] = make([]*big.Rat, m.Cols)
+		for j, cell := range row {
+			substituted, err := cell.Substitute(name, value)
+			if err != nil {
+				return Undefined
+			}
+			values[i][j], _ = substituted.Constant()
+		}
+	}
+
+	rankA, rankAb := ranks(values)
+	return classify(rankA, rankAb, m.Cols-1)
+}
+
+func (m *Matrix) solutionsAtFloat(name string, x float64) Solutions {
+	values := make([][]float64, m.Rows)
+	for i, row := range m.Data {
+		values[i] = make([]float64, m.Cols)
+		for j, cell := range row {
+			den := cell.Den.evaluate(name, x)
+			if math.Abs(den) < 1e-9 {
+				return Undefined
+			}
+			values[i][j] = cell.Num.evaluate(name, x) / den
+		}
+	}
+
+	rankA, rankAb := ranksFloat(values)
+	return classify(rankA, rankAb, m.Cols-1)
+}
+
+// analyzes augmented matrix with at most one variable: reports the kind
+// of the system for every value of the variable. critical values are roots
+// of the pivots of elimination (for a square system they are the roots of
+// the main determinant) and roots of the denominators in cells.
+// the rank condition is checked exactly at rational values
+// and numerically at irrational ones
+func (m *Matrix) Analyze() (*Analysis, error) {
+	if !m.Augmented {
+		return nil, errors.New("matrix is not augmented")
+	}
+
+	names := m.Variables()
+	if len(names) > 1 {
+		return nil, fmt.Errorf("system should have one parameter, got %s", strings.Join(names, ", "))
+	}
+
+	analysis := &Analysis{}
+	if m.Square {
+		analysis.Determinant, _ = m.Determinant()
+	}
+
+	pivots, cols := eliminate(m.Data)
+	rankA := len(pivots)
+	if rankA > 0 && cols[rankA-1] == m.Cols-1 {
+		rankA--
+	}
+	analysis.Generic = classify(rankA, len(pivots), m.Cols-1)
+
+	if len(names) == 0 {
+		return analysis, nil
+	}
+	name := names[0]
+	analysis.Parameter = name
+
+	polynomials := make([]Polynomial, 0, len(pivots))
+	for _, pivot := range pivots {
+		polynomials = append(polynomials, pivot.Num, pivot.Den)
+	}
+	for _, row := range m.Data {
+		for _, cell := range row {
+			polynomials = append(polynomials, cell.Den)
+		}
+	}
+
+	var exact []*big.Rat
+	var approx []float64
+	for _, p := range polynomials {
+		rational, irrational := roots(p, name)
+		for _, root := range rational {
+			if !slices.ContainsFunc(exact, func(r *big.Rat) bool { return r.Cmp(root) == 0 }) {
+				exact = append(exact, root)
+			}
+		}
+		for _, root := range irrational {
+			if !slices.ContainsFunc(approx, func(x float64) bool { return math.Abs(x-root) < 1e-9*max(1, math.Abs(x)) }) {
+				approx = append(approx, root)
+			}
+		}
+	}
+
+	for _, value := range exact {
+		approx, _ := value.Float64()
+		analysis.Critical = append(analysis.Critical, CriticalValue{value, approx, m.solutionsAt(name, value)})
+	}
+	for _, x := range approx {
+		analysis.Critical = append(analysis.Critical, CriticalValue{nil, x, m.solutionsAtFloat(name, x)})
+	}
+
+	// values that do not change the kind of the system are not critical
+	analysis.Critical = slices.DeleteFunc(analysis.Critical, func(c CriticalValue) bool {
+		return c.Solutions == analysis.Generic
+	})
+	slices.SortFunc(analysis.Critical, func(a, b CriticalValue) int {
+		if a.Value != nil && b.Value != nil {
+			return a.Value.Cmp(b.Value)
+		}
+		return cmp.Compare(a.Approx, b.Approx)
+	})
+
+	return analysis, nil
+}
+
+func (c CriticalValue) String() string {
+	if c.Value != nil {
+		return formatRat(c.Value)
+	}
+	return fmt.Sprintf("%.6g", c.Approx)
+}
+
+func (a *Analysis) String() string {
+	var sb strings.Builder
+
+	if a.Determinant != nil {
+		fmt.Fprintf(&sb, "det = %s\n", a.Determinant)
+	}
+
+	if a.Parameter == "" {
+		sb.WriteString(a.Generic.String() + "\n")
+		return sb.String()
+	}
+
+	values := make([]string, len(a.Critical))
+	for i, c := range a.Critical {
+		sign := "="
+		if c.Value == nil {
+			sign = "≈"
+		}
+		fmt.Fprintf(&sb, "%s %s %s: %s\n", a.Parameter, sign, c, c.Solutions)
+		values[i] = c.String()
+	}
+
+	if len(a.Critical) == 0 {
+		fmt.Fprintf(&sb, "for all %s: %s\n", a.Parameter, a.Generic)
+	} else {
+		fmt.Fprintf(&sb, "%s ∉ {%s}: %s\n", a.Parameter, strings.Join(values, ", "), a.Generic)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,245 @@
+package symbolic
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// matrix with rational functions in cells
+type Matrix struct {
+	Data      [][]*Rational
+	Rows      int
+	Cols      int
+	Augmented bool
+	Square    bool
+}
+
+func NewMatrix(data [][]*Rational, augmented bool) (*Matrix, error) {
+	if len(data) == 0 || len(data[0]) == 0 {
+		return nil, errors.New("error: data is empty")
+	}
+
+	rows, cols := len(data), len(data[0])
+	for _, row := range data {
+		if len(row) != cols {
+			return nil, errors.New("error: data is not rectangle-shaped")
+		}
+	}
+
+	square := rows == cols
+	if augmented {
+		square = rows == cols-1
+	}
+
+	return &Matrix{
+		Data:      data,
+		Rows:      rows,
+		Cols:      cols,
+		Augmented: augmented,
+		Square:    square,
+	}, nil
+}
+
+// parses every cell with Parse
+func ParseMatrix(cells [][]string, augmented bool) (*Matrix, error) {
+	data := make([][]*Rational, len(cells))
+	for i, row := range cells {
+		data[i] = make([]*Rational, len(row))
+		for j, cell := range row {
+			value, err := Parse(cell)
+			if err != nil {
+				return nil, fmt.Errorf("cell (%d, %d): %w", i+1, j+1, err)
+			}
+			data[i][j] = value
+		}
+	}
+
+	return NewMatrix(data, augmented)
+}
+
+// converts real values to exact rationals using their shortest decimal form
+func NewMatrixFromReal(data [][]float64, augmented bool) (*Matrix, error) {
+	cells := make([][]*Rational, len(data))
+	for i, row := range data {
+		cells[i] = make([]*Rational, len(row))
+		for j, value := range row {
+			r, ok := new(big.Rat).SetString(strconv.FormatFloat(value, 'g', -1, 64))
+			if !ok {
+				return nil, fmt.Errorf("value %v at (%d, %d) is not finite", value, i+1, j+1)
+			}
+			cells[i][j] = FromRat(r)
+		}
+	}
+
+	return NewMatrix(cells, augmented)
+}
+
+// returns values of the constant cells, other cells become 0
+func (m *Matrix) Real() [][]float64 {
+	data := make([][]float64, m.Rows)
+	for i, row := range m.Data {
+		data[i] = make([]float64, m.Cols)
+		for j, cell := range row {
+			if value, ok := cell.Constant(); ok {
+				data[i][j], _ = value.Float64()
+			}
+		}
+	}
+	return data
+}
+
+// returns sorted names of all variables in the matrix
+func (m *Matrix) Variables() []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, row := range m.Data {
+		for _, cell := range row {
+			for _, p := range []Polynomial{cell.Num, cell.Den} {
+				for _, name := range p.Variables() {
+					if !seen[name] {
+						seen[name] = true
+						names = append(names, name)
+					}
+				}
+			}
+		}
+	}
+
+	slices.Sort(names)
+	return names
+}
+
+func (m *Matrix) coefficients() [][]*Rational {
+	if !m.Augmented {
+		return m.Data
+	}
+
+	coefficients := make([][]*Rational, m.Rows)
+	for i, row := range m.Data {
+		coefficients[i] = row[:m.Cols-1]
+	}
+	return coefficients
+}
+
+// calculates determinant with fraction-free Bareiss elimination.
+// rows are multiplied by their denominators first, so that
+// every division in the elimination is exact
+func determinant(matrix [][]*Rational) *Rational {
+	n := len(matrix)
+	one := Constant(big.NewRat(1, 1))
+
+	a := make([][]Polynomial, n)
+	scale := one
+	for i, row := range matrix {
+		multiple := one
+		for _, cell := range row {
+			if _, ok := multiple.Divide(cell.Den); !ok {
+				multiple = multiple.Mul(cell.Den)
+			}
+		}
+		scale = scale.Mul(multiple)
+
+		a[i] = make([]Polynomial, n)
+		for j, cell := range row {
+			factor, _ := multiple.Divide(cell.Den)
+			a[i][j] = cell.Num.Mul(factor)
+		}
+	}
+
+	sign := Constant(big.NewRat(1, 1))
+	previous := one
+	for k := range n - 1 {
+		if a[k][k].IsZero() {
+			pivot := -1
+			for i := k + 1; i < n; i++ {
+				if !a[i][k].IsZero() {
+					pivot = i
+					break
+				}
+			}
+
+			if pivot == -1 {
+				return FromPolynomial(Polynomial{})
+			}
+
+			a[k], a[pivot] = a[pivot], a[k]
+			sign = sign.Neg()
+		}
+
+		for i := k + 1; i < n; i++ {
+			for j := k + 1; j < n; j++ {
+				value := a[i][j].Mul(a[k][k]).Sub(a[i][k].Mul(a[k][j]))
+				a[i][j], _ = value.Divide(previous)
+			}
+		}
+		previous = a[k][k]
+	}
+
+	det, _ := NewRational(a[n-1][n-1].Mul(sign), scale)
+	return det
+}
+
+// returns determinant of the matrix (of the coefficients, if augmented)
+func (m *Matrix) Determinant() (*Rational, error) {
+	if !m.Square {
+		return nil, errors.New("matrix is not a square")
+	}
+
+	return determinant(m.coefficients()), nil
+}
+
+// solves augmented matrix with Cramer's rule. the main determinant
+// should not be identically zero
+func (m *Matrix) Solve() ([]*Rational, error) {
+	if !m.Augmented {
+		return nil, errors.New("matrix is not augmented")
+	}
+
+	if !m.Square {
+		return nil, errors.New("matrix is not a square")
+	}
+
+	coefficients := m.coefficients()
+	main := determinant(coefficients)
+	if main.IsZero() {
+		return nil, errors.New("determinant is zero, system has no unique solution")
+	}
+
+	roots := make([]*Rational, m.Rows)
+	for k := range m.Rows {
+		replaced := make([][]*Rational, m.Rows)
+		for i, row := range coefficients {
+			replaced[i] = slices.Clone(row)
+			replaced[i][k] = m.Data[i][m.Cols-1]
+		}
+
+		roots[k], _ = determinant(replaced).Div(main)
+	}
+
+	return roots, nil
+}
+
+// returns formatted values, they can be parsed with ParseMatrix
+func (m *Matrix) Cells() [][]string {
+	cells := make([][]string, m.Rows)
+	for i, row := range m.Data {
+		cells[i] = make([]string, len(row))
+		for j, cell := range row {
+			cells[i][j] = cell.String()
+		}
+	}
+	return cells
+}
+
+func (m *Matrix) String() string {
+	rows := make([]string, m.Rows)
+	for i, row := range m.Cells() {
+		rows[i] = strings.Join(row, " ")
+	}
+
+	return strings.Join(rows, "\n")
+}
@@ -0,0 +1,240 @@
+package symbolic
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEnd tokenKind = iota
+	tokenNumber
+	tokenVariable
+	tokenOperator
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	// 1-based position of the first rune
+	pos int
+}
+
+// larger powers and degrees are rejected, they are too slow to expand
+const maxExponent = 1 << 10
+
+func errorf(pos int, format string, args ...any) error {
+	return fmt.Errorf("position %d: %s", pos, fmt.Sprintf(format, args...))
+}
+
+// numbers are decimal, variables are a letter followed by digits (a, x1, λ)
+func tokenize(input string) ([]token, error) {
+	runes := []rune(input)
+	var tokens []token
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		start := i
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+			continue
+		case unicode.IsDigit(r) || r == '.':
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{tokenNumber, string(runes[start:i]), start + 1})
+		case unicode.IsLetter(r):
+			i++
+			for i < len(runes) && unicode.IsDigit(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{tokenVariable, string(runes[start:i]), start + 1})
+		case r == '+' || r == '-' || r == '*' || r == '/' || r == '^' || r == '(' || r == ')':
+			i++
+			tokens = append(tokens, token{tokenOperator, string(r), start + 1})
+		default:
+			return nil, errorf(start+1, "unexpected character %q", r)
+		}
+	}
+
+	return append(tokens, token{tokenEnd, "", len(runes) + 1}), nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEnd {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isOperator(op string) bool {
+	t := p.peek()
+	return t.kind == tokenOperator && t.text == op
+}
+
+// parses expression like 2a-1, (a+1)/(a-1) or x^2y-3/2.
+// juxtaposition means multiplication, powers should be integers
+func Parse(input string) (*Rational, error) {
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	if p.peek().kind == tokenEnd {
+		return nil, errorf(1, "expression is empty")
+	}
+
+	r, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.kind != tokenEnd {
+		return nil, errorf(t.pos, "unexpected %q", t.text)
+	}
+
+	return r, nil
+}
+
+// expression := ['+' | '-'] term {('+' | '-') term}
+func (p *parser) expression() (*Rational, error) {
+	negative := false
+	if p.isOperator("+") || p.isOperator("-") {
+		negative = p.next().text == "-"
+	}
+
+	r, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	if negative {
+		r = r.Neg()
+	}
+
+	for p.isOperator("+") || p.isOperator("-") {
+		op := p.next()
+		s, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+
+		if op.text == "+" {
+			r = r.Add(s)
+		} else {
+			r = r.Sub(s)
+		}
+	}
+
+	return r, nil
+}
+
+// term := power {('*' | '/' | juxtaposition) power}
+func (p *parser) term() (*Rational, error) {
+	r, err := p.power()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		t := p.peek()
+		switch {
+		case p.isOperator("*") || p.isOperator("/"):
+			p.next()
+		case t.kind == tokenVariable || p.isOperator("("):
+		default:
+			return r, nil
+		}
+
+		s, err := p.power()
+		if err != nil {
+			return nil, err
+		}
+
+		if t.text == "/" {
+			if r, err = r.Div(s); err != nil {
+				return nil, errorf(t.pos, "%s", err)
+			}
+		} else {
+			r = r.Mul(s)
+		}
+	}
+}
+
+// power := primary ['^' ['-'] integer]
+func (p *parser) power() (*Rational, error) {
+	r, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.isOperator("^") {
+		return r, nil
+	}
+	caret := p.next()
+
+	sign := 1
+	if p.isOperator("-") {
+		p.next()
+		sign = -1
+	}
+
+	t := p.next()
+	exp, err := strconv.Atoi(t.text)
+	if t.kind != tokenNumber || err != nil {
+		return nil, errorf(t.pos, "power should be an integer")
+	}
+	if exp > maxExponent || exp*max(r.Num.TotalDegree(), r.Den.TotalDegree()) > maxExponent {
+		return nil, errorf(t.pos, "power should make degree at most %d, got %d", maxExponent, exp)
+	}
+
+	if r, err = r.Pow(sign * exp); err != nil {
+		return nil, errorf(caret.pos, "%s", err)
+	}
+	return r, nil
+}
+
+// primary := number | variable | '(' expression ')'
+func (p *parser) primary() (*Rational, error) {
+	t := p.next()
+
+	switch {
+	case t.kind == tokenNumber:
+		value, ok := new(big.Rat).SetString(t.text)
+		if !ok {
+			return nil, errorf(t.pos, "invalid number %q", t.text)
+		}
+		return FromRat(value), nil
+	case t.kind == tokenVariable:
+		return FromPolynomial(Variable(t.text)), nil
+	case t.kind == tokenOperator && t.text == "(":
+		r, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+
+		if closing := p.next(); closing.kind != tokenOperator || closing.text != ")" {
+			return nil, errorf(closing.pos, "expected ')'")
+		}
+		return r, nil
+	case t.kind == tokenEnd:
+		return nil, errorf(t.pos, "unexpected end of expression")
+	default:
+		return nil, errorf(t.pos, "unexpected %q", t.text)
+	}
+}
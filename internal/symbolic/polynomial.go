@@ -0,0 +1,374 @@
+package symbolic
+
+import (
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// power of a variable in a monomial, exp is always positive
+type power struct {
+	name string
+	exp  int
+}
+
+// product of variables sorted by name, empty for constants
+type monomial []power
+
+func (m monomial) key() string {
+	var sb strings.Builder
+	for _, p := range m {
+		sb.WriteString(p.name + "^" + strconv.Itoa(p.exp) + " ")
+	}
+	return sb.String()
+}
+
+func (m monomial) mul(n monomial) monomial {
+	product := make(monomial, 0, len(m)+len(n))
+
+	i, j := 0, 0
+	for i < len(m) && j < len(n) {
+		switch {
+		case m[i].name < n[j].name:
+			product = append(product, m[i])
+			i++
+		case m[i].name > n[j].name:
+			product = append(product, n[j])
+			j++
+		default:
+			product = append(product, power{m[i].name, m[i].exp + n[j].exp})
+			i++
+			j++
+		}
+	}
+
+	product = append(product, m[i:]...)
+	return append(product, n[j:]...)
+}
+
+func (m monomial) degree(name string) int {
+	for _, p := range m {
+		if p.name == name {
+			return p.exp
+		}
+	}
+	return 0
+}
+
+// reports whether m divides n
+func (m monomial) divides(n monomial) bool {
+	for _, p := range m {
+		if n.degree(p.name) < p.exp {
+			return false
+		}
+	}
+	return true
+}
+
+// returns n / m, m should divide n
+func (m monomial) quotient(n monomial) monomial {
+	var quotient monomial
+	for _, p := range n {
+		if exp := p.exp - m.degree(p.name); exp > 0 {
+			quotient = append(quotient, power{p.name, exp})
+		}
+	}
+	return quotient
+}
+
+// lexicographic order with variables sorted by name:
+// a > b > ... and a^2 > a*b^5 > a > b
+func compareMonomials(m, n monomial) int {
+	i := 0
+	for ; i < len(m) && i < len(n); i++ {
+		switch {
+		case m[i].name < n[i].name:
+			return 1
+		case m[i].name > n[i].name:
+			return -1
+		case m[i].exp != n[i].exp:
+			return m[i].exp - n[i].exp
+		}
+	}
+
+	return len(m) - len(n)
+}
+
+type term struct {
+	mono monomial
+	coef *big.Rat
+}
+
+// multivariate polynomial with rational coefficients.
+// polynomials are immutable, operations return new values
+type Polynomial struct {
+	// sorted by monomial in descending order, coefficients are not zero
+	terms []term
+}
+
+// sums equal monomials, drops zero coefficients and sorts terms
+func newPolynomial(terms []term) Polynomial {
+	sums := make(map[string]*term)
+	var keys []string
+
+	for _, t := range terms {
+		key := t.mono.key()
+		if sum, ok := sums[key]; ok {
+			sum.coef.Add(sum.coef, t.coef)
+			continue
+		}
+
+		sums[key] = &term{t.mono, new(big.Rat).Set(t.coef)}
+		keys = append(keys, key)
+	}
+
+	var p Polynomial
+	for _, key := range keys {
+		if t := sums[key]; t.coef.Sign() != 0 {
+			p.terms = append(p.terms, *t)
+		}
+	}
+
+	slices.SortFunc(p.terms, func(a, b term) int {
+		return compareMonomials(b.mono, a.mono)
+	})
+
+	return p
+}
+
+func Constant(c *big.Rat) Polynomial {
+	return newPolynomial([]term{{nil, c}})
+}
+
+func Variable(name string) Polynomial {
+	return Polynomial{[]term{{monomial{{name, 1}}, big.NewRat(1, 1)}}}
+}
+
+func (p Polynomial) IsZero() bool {
+	return len(p.terms) == 0
+}
+
+// returns value of the polynomial if it has no variables
+func (p Polynomial) Constant() (*big.Rat, bool) {
+	switch {
+	case p.IsZero():
+		return new(big.Rat), true
+	case len(p.terms) == 1 && len(p.terms[0].mono) == 0:
+		return new(big.Rat).Set(p.terms[0].coef), true
+	default:
+		return nil, false
+	}
+}
+
+// returns sorted names of the variables
+func (p Polynomial) Variables() []string {
+	var names []string
+	for _, t := range p.terms {
+		for _, power := range t.mono {
+			if !slices.Contains(names, power.name) {
+				names = append(names, power.name)
+			}
+		}
+	}
+
+	slices.Sort(names)
+	return names
+}
+
+// returns the highest power of the variable
+func (p Polynomial) Degree(name string) int {
+	degree := 0
+	for _, t := range p.terms {
+		degree = max(degree, t.mono.degree(name))
+	}
+	return degree
+}
+
+// returns the largest sum of powers in the terms
+func (p Polynomial) TotalDegree() int {
+	degree := 0
+	for _, t := range p.terms {
+		sum := 0
+		for _, power := range t.mono {
+			sum += power.exp
+		}
+		degree = max(degree, sum)
+	}
+	return degree
+}
+
+func (p Polynomial) Equal(q Polynomial) bool {
+	return p.Sub(q).IsZero()
+}
+
+func (p Polynomial) Add(q Polynomial) Polynomial {
+	return newPolynomial(slices.Concat(p.terms, q.terms))
+}
+
+func (p Polynomial) Neg() Polynomial {
+	return p.Scale(big.NewRat(-1, 1))
+}
+
+func (p Polynomial) Sub(q Polynomial) Polynomial {
+	return p.Add(q.Neg())
+}
+
+func (p Polynomial) Scale(c *big.Rat) Polynomial {
+	terms := make([]term, len(p.terms))
+	for i, t := range p.terms {
+		terms[i] = term{t.mono, new(big.Rat).Mul(t.coef, c)}
+	}
+	return newPolynomial(terms)
+}
+
+func (p Polynomial) Mul(q Polynomial) Polynomial {
+	terms := make([]term, 0, len(p.terms)*len(q.terms))
+	for _, a := range p.terms {
+		for _, b := range q.terms {
+			terms = append(terms, term{a.mono.mul(b.mono), new(big.Rat).Mul(a.coef, b.coef)})
+		}
+	}
+	return newPolynomial(terms)
+}
+
+// returns p^n for n >= 0 with binary exponentiation
+func (p Polynomial) Pow(n int) Polynomial {
+	result := Constant(big.NewRat(1, 1))
+	for square := p; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			result = result.Mul(square)
+		}
+		if n > 1 {
+			square = square.Mul(square)
+		}
+	}
+	return result
+}
+
+// replaces the variable with the value
+func (p Polynomial) Substitute(name string, value *big.Rat) Polynomial {
+	terms := make([]term, len(p.terms))
+	for i, t := range p.terms {
+		coef := new(big.Rat).Set(t.coef)
+		var mono monomial
+		for _, power := range t.mono {
+			if power.name != name {
+				mono = append(mono, power)
+				continue
+			}
+
+			for range power.exp {
+				coef.Mul(coef, value)
+			}
+		}
+		terms[i] = term{mono, coef}
+	}
+	return newPolynomial(terms)
+}
+
+func (p Polynomial) leading() term {
+	return p.terms[0]
+}
+
+// multivariate division: p = quotient * q + remainder, where no term
+// of the remainder is divisible by the leading term of q.
+// q should not be zero
+func divide(p, q Polynomial) (quotient, remainder Polynomial) {
+	lead := q.leading()
+
+	var quotientTerms, remainderTerms []term
+	for !p.IsZero() {
+		t := p.leading()
+		if !lead.mono.divides(t.mono) {
+			remainderTerms = append(remainderTerms, t)
+			p = Polynomial{p.terms[1:]}
+			continue
+		}
+
+		factor := term{lead.mono.quotient(t.mono), new(big.Rat).Quo(t.coef, lead.coef)}
+		quotientTerms = append(quotientTerms, factor)
+		p = p.Sub(Polynomial{[]term{factor}}.Mul(q))
+	}
+
+	return newPolynomial(quotientTerms), newPolynomial(remainderTerms)
+}
+
+// returns p / q if q divides p exactly
+func (p Polynomial) Divide(q Polynomial) (Polynomial, bool) {
+	if q.IsZero() {
+		return Polynomial{}, false
+	}
+
+	quotient, remainder := divide(p, q)
+	return quotient, remainder.IsZero()
+}
+
+// makes leading coefficient equal to 1
+func (p Polynomial) monic() Polynomial {
+	if p.IsZero() {
+		return p
+	}
+	return p.Scale(new(big.Rat).Inv(p.leading().coef))
+}
+
+// returns monic greatest common divisor of polynomials in one variable
+// with the euclidean algorithm. ok is false for multivariate polynomials
+func gcd(p, q Polynomial) (Polynomial, bool) {
+	names := slices.Concat(p.Variables(), q.Variables())
+	slices.Sort(names)
+	if len(slices.Compact(names)) > 1 {
+		return Polynomial{}, false
+	}
+
+	for !q.IsZero() {
+		_, remainder := divide(p, q)
+		p, q = q, remainder
+	}
+
+	return p.monic(), true
+}
+
+func formatRat(r *big.Rat) string {
+	if r.IsInt() {
+		return r.Num().String()
+	}
+	return r.String()
+}
+
+func (t term) String() string {
+	var sb strings.Builder
+
+	coef := new(big.Rat).Abs(t.coef)
+	if len(t.mono) == 0 || coef.Cmp(big.NewRat(1, 1)) != 0 {
+		sb.WriteString(formatRat(coef))
+	}
+
+	for _, power := range t.mono {
+		sb.WriteString(power.name)
+		if power.exp != 1 {
+			sb.WriteString("^" + strconv.Itoa(power.exp))
+		}
+	}
+
+	return sb.String()
+}
+
+// formats polynomial without spaces, like 2a^2b-a+1/2
+func (p Polynomial) String() string {
+	if p.IsZero() {
+		return "0"
+	}
+
+	var sb strings.Builder
+	for i, t := range p.terms {
+		if t.coef.Sign() < 0 {
+			sb.WriteString("-")
+		} else if i > 0 {
+			sb.WriteString("+")
+		}
+		sb.WriteString(t.String())
+	}
+
+	return sb.String()
+}
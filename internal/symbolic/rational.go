@@ -0,0 +1,148 @@
+package symbolic
+
+import (
+	"errors"
+	"math/big"
+)
+
+// rational function Num / Den. the denominator is never zero
+// and its leading coefficient is 1
+type Rational struct {
+	Num Polynomial
+	Den Polynomial
+}
+
+func NewRational(num, den Polynomial) (*Rational, error) {
+	if den.IsZero() {
+		return nil, errors.New("division by zero")
+	}
+
+	return simplify(num, den), nil
+}
+
+func FromPolynomial(p Polynomial) *Rational {
+	return &Rational{p, Constant(big.NewRat(1, 1))}
+}
+
+func FromRat(r *big.Rat) *Rational {
+	return FromPolynomial(Constant(r))
+}
+
+// cancels common factors. for polynomials in one variable the gcd is
+// cancelled, otherwise only exact division of one part by the other
+func simplify(num, den Polynomial) *Rational {
+	one := Constant(big.NewRat(1, 1))
+
+	if num.IsZero() {
+		return &Rational{num, one}
+	}
+
+	if divisor, ok := gcd(num, den); ok {
+		num, _ = num.Divide(divisor)
+		den, _ = den.Divide(divisor)
+	} else if quotient, ok := num.Divide(den); ok {
+		num, den = quotient, one
+	} else if quotient, ok := den.Divide(num); ok {
+		num, den = one, quotient
+	}
+
+	lead := new(big.Rat).Inv(den.leading().coef)
+	return &Rational{num.Scale(lead), den.Scale(lead)}
+}
+
+func (r *Rational) IsZero() bool {
+	return r.Num.IsZero()
+}
+
+// returns polynomial if the denominator is constant
+func (r *Rational) Polynomial() (Polynomial, bool) {
+	if _, ok := r.Den.Constant(); !ok {
+		return Polynomial{}, false
+	}
+	return r.Num, true
+}
+
+// returns value if the function has no variables
+func (r *Rational) Constant() (*big.Rat, bool) {
+	if p, ok := r.Polynomial(); ok {
+		return p.Constant()
+	}
+	return nil, false
+}
+
+func (r *Rational) Add(s *Rational) *Rational {
+	if r.Den.Equal(s.Den) {
+		return simplify(r.Num.Add(s.Num), r.Den)
+	}
+	return simplify(r.Num.Mul(s.Den).Add(s.Num.Mul(r.Den)), r.Den.Mul(s.Den))
+}
+
+func (r *Rational) Neg() *Rational {
+	return &Rational{r.Num.Neg(), r.Den}
+}
+
+func (r *Rational) Sub(s *Rational) *Rational {
+	return r.Add(s.Neg())
+}
+
+func (r *Rational) Mul(s *Rational) *Rational {
+	return simplify(r.Num.Mul(s.Num), r.Den.Mul(s.Den))
+}
+
+func (r *Rational) Div(s *Rational) (*Rational, error) {
+	if s.IsZero() {
+		return nil, errors.New("division by zero")
+	}
+	return simplify(r.Num.Mul(s.Den), r.Den.Mul(s.Num)), nil
+}
+
+// returns r^n, negative n is allowed for non-zero r
+func (r *Rational) Pow(n int) (*Rational, error) {
+	if n >= 0 {
+		return &Rational{r.Num.Pow(n), r.Den.Pow(n)}, nil
+	}
+
+	if r.IsZero() {
+		return nil, errors.New("division by zero")
+	}
+	return simplify(r.Den.Pow(-n), r.Num.Pow(-n)), nil
+}
+
+// replaces the variable with the value. fails if the denominator becomes zero
+func (r *Rational) Substitute(name string, value *big.Rat) (*Rational, error) {
+	return NewRational(r.Num.Substitute(name, value), r.Den.Substitute(name, value))
+}
+
+func (r *Rational) Equal(s *Rational) bool {
+	return r.Num.Mul(s.Den).Equal(s.Num.Mul(r.Den))
+}
+
+// parentheses are needed for everything except a number or a single power
+func needsParentheses(p Polynomial) bool {
+	if len(p.terms) != 1 {
+		return true
+	}
+
+	t := p.terms[0]
+	if len(t.mono) == 0 {
+		return t.coef.Sign() < 0 || !t.coef.IsInt()
+	}
+	return len(t.mono) > 1 || t.coef.Cmp(big.NewRat(1, 1)) != 0
+}
+
+// formats function without spaces, like (a+1)/(a-1). the result can be parsed back
+func (r *Rational) String() string {
+	if _, ok := r.Den.Constant(); ok {
+		return r.Num.String()
+	}
+
+	num, den := r.Num.String(), r.Den.String()
+	if len(r.Num.terms) > 1 {
+		num = "(" + num + ")"
+	}
+	if needsParentheses(r.Den) {
+		den = "(" + den + ")"
+	}
+
+	return num + "/" + den
+}
@@ -0,0 +1,236 @@
+package symbolic
+
+import (
+	"math"
+	"math/big"
+	"math/cmplx"
+	"slices"
+)
+
+// returns coefficients of the polynomial in one variable, index is the power
+func (p Polynomial) coefficients(name string) []*big.Rat {
+	coefficients := make([]*big.Rat, p.Degree(name)+1)
+	for i := range coefficients {
+		coefficients[i] = new(big.Rat)
+	}
+
+	for _, t := range p.terms {
+		coefficients[t.mono.degree(name)].Add(coefficients[t.mono.degree(name)], t.coef)
+	}
+
+	return coefficients
+}
+
+func (p Polynomial) Derivative(name string) Polynomial {
+	var terms []term
+	for _, t := range p.terms {
+		exp := t.mono.degree(name)
+		if exp == 0 {
+			continue
+		}
+
+		mono := slices.Clone(t.mono)
+		for i := range mono {
+			if mono[i].name == name {
+				mono[i].exp--
+			}
+		}
+		mono = slices.DeleteFunc(mono, func(p power) bool { return p.exp == 0 })
+
+		terms = append(terms, term{mono, new(big.Rat).Mul(t.coef, big.NewRat(int64(exp), 1))})
+	}
+	return newPolynomial(terms)
+}
+
+// value of the polynomial in one variable at x
+func (p Polynomial) evaluate(name string, x float64) float64 {
+	value := 0.0
+	for _, t := range p.terms {
+		coef, _ := t.coef.Float64()
+		value += coef * math.Pow(x, float64(t.mono.degree(name)))
+	}
+	return value
+}
+
+func hornerRat(coefficients []*big.Rat, x *big.Rat) *big.Rat {
+	value := new(big.Rat)
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		value.Mul(value, x)
+		value.Add(value, coefficients[i])
+	}
+	return value
+}
+
+// divides polynomial by (x - root), root should be a root
+func deflate(coefficients []*big.Rat, root *big.Rat) []*big.Rat {
+	n := len(coefficients) - 1
+	quotient := make([]*big.Rat, n)
+
+	carry := new(big.Rat)
+	for i := n; i >= 1; i-- {
+		carry = new(big.Rat).Add(coefficients[i], new(big.Rat).Mul(carry, root))
+		quotient[i-1] = carry
+	}
+
+	return quotient
+}
+
+// divisors of |n| are searched by trial division, so large values are skipped
+const maxRootCandidate = 1 << 40
+
+func divisors(n *big.Int) []int64 {
+	if n.BitLen() > 62 {
+		return nil
+	}
+
+	value := new(big.Int).Abs(n).Int64()
+	if value > maxRootCandidate {
+		return nil
+	}
+
+	var small, large []int64
+	for d := int64(1); d*d <= value; d++ {
+		if value%d == 0 {
+			small = append(small, d)
+			if d*d != value {
+				large = append(large, value/d)
+			}
+		}
+	}
+
+	slices.Reverse(large)
+	return append(small, large...)
+}
+
+// returns distinct rational roots with the rational root theorem
+// and coefficients of the polynomial left after dividing them out
+func rationalRoots(coefficients []*big.Rat) ([]*big.Rat, []*big.Rat) {
+	var roots []*big.Rat
+
+	for len(coefficients) > 1 && coefficients[0].Sign() == 0 {
+		if len(roots) == 0 {
+			roots = append(roots, new(big.Rat))
+		}
+		coefficients = coefficients[1:]
+	}
+
+	if len(coefficients) <= 1 {
+		return roots, coefficients
+	}
+
+	// integer coefficients have the same roots
+	lcm := big.NewInt(1)
+	for _, c := range coefficients {
+		gcd := new(big.Int).GCD(nil, nil, lcm, c.Denom())
+		lcm.Mul(lcm, new(big.Int).Quo(c.Denom(), gcd))
+	}
+	constant := new(big.Int).Mul(coefficients[0].Num(), new(big.Int).Quo(lcm, coefficients[0].Denom()))
+	last := coefficients[len(coefficients)-1]
+	leading := new(big.Int).Mul(last.Num(), new(big.Int).Quo(lcm, last.Denom()))
+
+	for _, p := range divisors(constant) {
+		for _, q := range divisors(leading) {
+			for _, sign := range []int64{1, -1} {
+				candidate := big.NewRat(sign*p, q)
+				if slices.ContainsFunc(roots, func(r *big.Rat) bool { return r.Cmp(candidate) == 0 }) {
+					continue
+				}
+
+				found := false
+				for len(coefficients) > 1 && hornerRat(coefficients, candidate).Sign() == 0 {
+					coefficients = deflate(coefficients, candidate)
+					found = true
+				}
+				if found {
+					roots = append(roots, candidate)
+				}
+			}
+		}
+	}
+
+	return roots, coefficients
+}
+
+// returns real roots of square-free polynomial with Durand-Kerner method
+func realRoots(coefficients []float64) []float64 {
+	n := len(coefficients) - 1
+	if n < 1 {
+		return nil
+	}
+
+	monic := make([]complex128, n+1)
+	for i, c := range coefficients {
+		monic[i] = complex(c/coefficients[n], 0)
+	}
+
+	value := func(z complex128) complex128 {
+		result := complex(0, 0)
+		for i := n; i >= 0; i-- {
+			result = result*z + monic[i]
+		}
+		return result
+	}
+
+	roots := make([]complex128, n)
+	for k := range n {
+		roots[k] = cmplx.Pow(complex(0.4, 0.9), complex(float64(k), 0))
+	}
+
+	for range 1000 {
+		change := 0.0
+		for k := range n {
+			denominator := complex(1, 0)
+			for j := range n {
+				if j != k {
+					denominator *= roots[k] - roots[j]
+				}
+			}
+
+			step := value(roots[k]) / denominator
+			roots[k] -= step
+			change = max(change, cmplx.Abs(step))
+		}
+
+		if change < 1e-14 {
+			break
+		}
+	}
+
+	var values []float64
+	for _, root := range roots {
+		if math.Abs(imag(root)) < 1e-7*max(1, cmplx.Abs(root)) {
+			values = append(values, real(root))
+		}
+	}
+
+	slices.Sort(values)
+	return values
+}
+
+// returns exact rational roots and approximate irrational real roots
+// of the polynomial in one variable
+func roots(p Polynomial, name string) ([]*big.Rat, []float64) {
+	if p.Degree(name) == 0 {
+		return nil, nil
+	}
+
+	exact, rest := rationalRoots(p.coefficients(name))
+	if len(rest) <= 1 {
+		return exact, nil
+	}
+
+	// multiple roots slow down the iteration, so only the square-free part is used
+	q := newPolynomial(nil)
+	for i, c := range rest {
+		q = q.Add(Constant(c).Mul(Variable(name).Pow(i)))
+	}
+	divisor, _ := gcd(q, q.Derivative(name))
+	q, _ = q.Divide(divisor)
+
+	coefficients := make([]float64, q.Degree(name)+1)
+	for i, c := range q.coefficients(name) {
+		coefficients[i], _ = c.Float64()
+	}
+
+	return exact, realRoots(coefficients)
+}
@@ -0,0 +1,256 @@
+package symbolic
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	cmatrix "github.com/shimeoki/mlat/internal/matrix"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"2a-1", "2a-1"},
+		{"2 * a - 1", "2a-1"},
+		{"-a+3", "-a+3"},
+		{"(a+1)(a-1)", "a^2-1"},
+		{"(a+b)^2", "a^2+2ab+b^2"},
+		{"x^2y", "x^2y"},
+		{"3/2a", "3/2a"},
+		{"0.5λ", "1/2λ"},
+		{"x1x2-x2", "x1x2-x2"},
+		{"(a^2-1)/(a-1)", "a+1"},
+		{"(a+1)/(2a-2)", "(1/2a+1/2)/(a-1)"},
+		{"1/a^2", "1/a^2"},
+		{"a^-1", "1/a"},
+		{"(a+1)^5", "a^5+5a^4+10a^3+10a^2+5a+1"},
+		{"2/(ab)", "2/(ab)"},
+		{"a/b", "a/b"},
+		{"a-a", "0"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input,
+			func(t *testing.T) {
+				r, err := Parse(test.input)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if ans := r.String(); ans != test.want {
+					t.Fatalf("got %q, want %q", ans, test.want)
+				}
+
+				// formatted function should be parsed back to the same value
+				if again, _ := Parse(r.String()); !again.Equal(r) {
+					t.Errorf("%q is parsed as %q", r, again)
+				}
+			})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", "position 1: expression is empty"},
+		{"2a-", "position 4: unexpected end of expression"},
+		{"(a+1", "position 5: expected ')'"},
+		{"a^b", "position 3: power should be an integer"},
+		{"2^99999999", "position 3: power should make degree at most 1024, got 99999999"},
+		{"(a^100)^11", "position 9: power should make degree at most 1024, got 11"},
+		{"1/(a-a)", "position 2: division by zero"},
+		{"a$", "position 2: unexpected character '$'"},
+		{"a)", "position 2: unexpected \")\""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input,
+			func(t *testing.T) {
+				_, err := Parse(test.input)
+				if err == nil || err.Error() != test.want {
+					t.Errorf("got %v, want %q", err, test.want)
+				}
+			})
+	}
+}
+
+func TestPolynomialDivide(t *testing.T) {
+	p, _ := Parse("a^3b-ab^3")
+	q, _ := Parse("a+b")
+
+	quotient, ok := p.Num.Divide(q.Num)
+	if !ok || quotient.String() != "a^2b-ab^2" {
+		t.Errorf("got %q, %v", quotient, ok)
+	}
+
+	r, _ := Parse("a+1")
+	if _, ok := p.Num.Divide(r.Num); ok {
+		t.Error("a+1 should not divide the polynomial")
+	}
+
+	a, _ := Parse("x^3-x")
+	b, _ := Parse("x^2+2x+1")
+	divisor, _ := gcd(a.Num, b.Num)
+	if divisor.String() != "x+1" {
+		t.Errorf("gcd: got %q, want %q", divisor, "x+1")
+	}
+}
+
+func TestSubstitute(t *testing.T) {
+	r, _ := Parse("(a^2+b)/(a-1)")
+
+	ans, err := r.Substitute("a", big.NewRat(2, 1))
+	if err != nil || ans.String() != "b+4" {
+		t.Errorf("got %v, %v", ans, err)
+	}
+
+	if _, err := r.Substitute("a", big.NewRat(1, 1)); err == nil {
+		t.Error("expected error for zero denominator")
+	}
+}
+
+func TestReadWriteCells(t *testing.T) {
+	tests := []struct {
+		name      string
+		matrix    [][]string
+		augmented bool
+	}{
+		{"polynomials", [][]string{{"2a-1", "1/2"}, {"a^2", "(a+1)/b"}}, false},
+		{"augmented", [][]string{{"2a-1", "3/2a", "1"}, {"1", "1/(a-1)", "-a"}}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				m, err := ParseMatrix(test.matrix, test.augmented)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				var sb strings.Builder
+				if err := cmatrix.WriteCells(&sb, m.Cells(), m.Augmented); err != nil {
+					t.Fatal(err)
+				}
+
+				cells, augmented, err := cmatrix.ReadCells(strings.NewReader(sb.String()))
+				if err != nil {
+					t.Fatal(err)
+				}
+				ans, err := ParseMatrix(cells, augmented)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if ans.String() != m.String() || ans.Augmented != m.Augmented {
+					t.Errorf("\ngot:\n%s\nwant:\n%s\nfile:\n%s", ans, m, sb.String())
+				}
+			})
+	}
+}
+
+func TestDeterminant(t *testing.T) {
+	tests := []struct {
+		name   string
+		matrix [][]string
+		want   string
+	}{
+		{"numbers", [][]string{{"1", "2", "3"}, {"4", "5", "6"}, {"7", "8", "10"}}, "-3"},
+		{"characteristic", [][]string{{"1-λ", "2"}, {"3", "4-λ"}}, "λ^2-5λ-2"},
+		{"two variables", [][]string{{"a", "b"}, {"b", "a"}}, "a^2-b^2"},
+		{"zero pivot", [][]string{{"0", "a"}, {"b", "0"}}, "-ab"},
+		{"vandermonde", [][]string{{"1", "a", "a^2"}, {"1", "b", "b^2"}, {"1", "c", "c^2"}}, "-a^2b+a^2c+ab^2-ac^2-b^2c+bc^2"},
+		{"fractions", [][]string{{"1/a", "1"}, {"1", "a"}}, "0"},
+		{"rational", [][]string{{"1/a", "1"}, {"0", "1/(a+1)"}}, "1/(a^2+a)"},
+		{"singular", [][]string{{"a", "2a"}, {"1", "2"}}, "0"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				m, err := ParseMatrix(test.matrix, false)
+				if err != nil {
+					t.Fatal(err)
+				}
+				det, err := m.Determinant()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if ans := det.String(); ans != test.want {
+					t.Errorf("got %q, want %q", ans, test.want)
+				}
+			})
+	}
+}
+
+func TestSolve(t *testing.T) {
+	m, _ := ParseMatrix([][]string{{"a", "1", "1"}, {"1", "a", "1"}}, true)
+
+	roots, err := m.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, want := range []string{"1/(a+1)", "1/(a+1)"} {
+		if ans := roots[i].String(); ans != want {
+			t.Errorf("x%d: got %q, want %q", i+1, ans, want)
+		}
+	}
+
+	m, _ = ParseMatrix([][]string{{"a", "2a", "1"}, {"1", "2", "1"}}, true)
+	if _, err := m.Solve(); err == nil {
+		t.Error("expected error for zero determinant")
+	}
+
+	if names := m.Variables(); len(names) != 1 || names[0] != "a" {
+		t.Errorf("got variables %v", names)
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	tests := []struct {
+		name   string
+		matrix [][]string
+		want   string
+	}{
+		{"classic", [][]string{{"a", "1", "1", "1"}, {"1", "a", "1", "1"}, {"1", "1", "a", "1"}},
+			"det = a^3-3a+2\na = -2: no solutions\na = 1: infinitely many solutions\na ∉ {-2, 1}: unique solution\n"},
+		{"inconsistent", [][]string{{"1", "a", "1"}, {"a", "1", "1"}},
+			"det = -a^2+1\na = -1: no solutions\na = 1: infinitely many solutions\na ∉ {-1, 1}: unique solution\n"},
+		{"irrational", [][]string{{"a", "2", "1"}, {"1", "a", "0"}},
+			"det = a^2-2\na ≈ -1.41421: no solutions\na ≈ 1.41421: no solutions\na ∉ {-1.41421, 1.41421}: unique solution\n"},
+		{"undefined", [][]string{{"1/a", "1", "1"}, {"0", "1", "1"}},
+			"det = 1/a\na = 0: system is undefined\na ∉ {0}: unique solution\n"},
+		{"always unique", [][]string{{"1", "a", "1"}, {"0", "1", "a"}},
+			"det = 1\nfor all a: unique solution\n"},
+		{"rectangular", [][]string{{"1", "a", "1"}, {"2", "2a", "2"}, {"1", "1", "a"}},
+			"a = 1: infinitely many solutions\na ∉ {1}: unique solution\n"},
+		{"no parameter", [][]string{{"1", "1", "1"}, {"1", "1", "2"}},
+			"det = 0\nno solutions\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name,
+			func(t *testing.T) {
+				m, err := ParseMatrix(test.matrix, true)
+				if err != nil {
+					t.Fatal(err)
+				}
+				analysis, err := m.Analyze()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if ans := analysis.String(); ans != test.want {
+					t.Errorf("\ngot:\n%s\nwant:\n%s", ans, test.want)
+				}
+			})
+	}
+
+	m, _ := ParseMatrix([][]string{{"a", "b", "1"}, {"1", "1", "1"}}, true)
+	if _, err := m.Analyze(); err == nil {
+		t.Error("expected error for two parameters")
+	}
+}
@@ -0,0 +1,208 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/shimeoki/mlat/internal/expr"
+	cmatrix "github.com/shimeoki/mlat/internal/matrix"
+)
+
+type CalculatorTab struct {
+	Variables map[string]*cmatrix.Matrix
+	Names     []string
+	Selected  int
+
+	VariablesList      *widget.List
+	VariablesAdd       *widget.Button
+	VariablesAddDialog *dialog.FileDialog
+	VariablesRemove    *widget.Button
+	VariablesContainer *fyne.Container
+
+	MatrixResult         *cmatrix.Matrix
+	TableResult          *widget.Table
+	TableResultContainer *fyne.Container
+
+	ActionsExpression *widget.Entry
+	ActionsEvaluate   *widget.Button
+	ActionsAnswer     *widget.Entry
+	ActionsContainer  *fyne.Container
+
+	MainContainer *fyne.Container
+
+	GUI *GUI
+}
+
+func (p *GUI) newCalculatorTab() *CalculatorTab {
+	tab := &CalculatorTab{}
+	tab.GUI = p
+
+	tab.Variables = make(map[string]*cmatrix.Matrix)
+	tab.Selected = -1
+
+	tab.VariablesList = widget.NewList(
+		func() int {
+			return len(tab.Names)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("variable")
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			name := tab.Names[id]
+			m := tab.Variables[name]
+			item.(*widget.Label).SetText(fmt.Sprintf("%s: %dx%d", name, m.Rows, m.Cols))
+		},
+	)
+	tab.VariablesList.OnSelected = func(id widget.ListItemID) {
+		tab.Selected = id
+		tab.VariablesRemove.Enable()
+	}
+	tab.VariablesList.OnUnselected = func(widget.ListItemID) {
+		tab.Selected = -1
+		tab.VariablesRemove.Disable()
+	}
+
+	tab.VariablesAddDialog = newMatrixOpenDialog(tab.GUI.Window, func(data [][]float64) {
+		name := widget.NewEntry()
+		name.SetText(tab.freeName())
+		name.Validator = func(s string) error {
+			if !isIdentifier(s) {
+				return errors.New("name is not an identifier")
+			}
+			return nil
+		}
+
+		dialog.ShowForm("Variable", "Add", "Cancel",
+			[]*widget.FormItem{widget.NewFormItem("Name", name)},
+			func(confirmed bool) {
+				if !confirmed {
+					return
+				}
+				tab.setVariable(name.Text, data)
+			},
+			tab.GUI.Window,
+		)
+	})
+	tab.VariablesAdd = widget.NewButtonWithIcon(
+		"Add Variable",
+		theme.ContentAddIcon(),
+		func() {
+			tab.VariablesAddDialog.Show()
+		},
+	)
+
+	tab.VariablesRemove = widget.NewButtonWithIcon(
+		"Remove",
+		theme.ContentRemoveIcon(),
+		func() {
+			if tab.Selected < 0 || tab.Selected >= len(tab.Names) {
+				return
+			}
+
+			delete(tab.Variables, tab.Names[tab.Selected])
+			tab.Names = slices.Delete(tab.Names, tab.Selected, tab.Selected+1)
+			tab.VariablesList.UnselectAll()
+			tab.VariablesList.Refresh()
+		},
+	)
+	tab.VariablesRemove.Disable()
+
+	tab.VariablesContainer = container.NewPadded(container.NewBorder(
+		container.NewCenter(widget.NewLabelWithStyle(
+			"Variables", fyne.TextAlignCenter, fyne.TextStyle{Bold: true})),
+		container.NewGridWithColumns(2, tab.VariablesAdd, tab.VariablesRemove),
+		nil, nil, tab.VariablesList))
+
+	tab.MatrixResult, _ = cmatrix.NewBlankMatrix(1, 1, false)
+	tab.TableResult = createTable(&tab.MatrixResult)
+	tab.TableResultContainer = createTitledTable("Result", tab.TableResult)
+
+	tab.ActionsExpression = widget.NewEntry()
+	tab.ActionsExpression.SetPlaceHolder("inv(A) * B + 2*T(C) - det(A)*I")
+	tab.ActionsExpression.OnSubmitted = func(string) {
+		tab.evaluate()
+	}
+
+	tab.ActionsEvaluate = widget.NewButtonWithIcon(
+		"Evaluate",
+		theme.GridIcon(),
+		tab.evaluate,
+	)
+
+	tab.ActionsAnswer = widget.NewEntry()
+	tab.ActionsAnswer.SetPlaceHolder("Answer...")
+	tab.ActionsAnswer.Disable()
+
+	tab.ActionsContainer = container.NewPadded(container.NewBorder(
+		nil, tab.ActionsAnswer, nil, tab.ActionsEvaluate, tab.ActionsExpression,
+	))
+
+	tab.MainContainer = container.NewBorder(
+		nil, tab.ActionsContainer, tab.VariablesContainer, nil, tab.TableResultContainer,
+	)
+
+	return tab
+}
+
+func isIdentifier(s string) bool {
+	for i, r := range s {
+		letter := r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		digit := r >= '0' && r <= '9'
+		if !letter && (i == 0 || !digit) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// returns the first letter that is not used by variables. I is skipped
+func (p *CalculatorTab) freeName() string {
+	for r := 'A'; r <= 'Z'; r++ {
+		name := string(r)
+		if _, ok := p.Variables[name]; !ok && name != "I" {
+			return name
+		}
+	}
+	return ""
+}
+
+func (p *CalculatorTab) setVariable(name string, data [][]float64) {
+	m, err := cmatrix.NewMatrix(data, false)
+	if err != nil {
+		dialog.ShowInformation("Error!", err.Error(), p.GUI.Window)
+		return
+	}
+
+	if _, ok := p.Variables[name]; !ok {
+		p.Names = append(p.Names, name)
+		slices.Sort(p.Names)
+	}
+
+	p.Variables[name] = m
+	p.VariablesList.Refresh()
+}
+
+func (p *CalculatorTab) evaluate() {
+	value, err := expr.Evaluate(p.ActionsExpression.Text, p.Variables)
+	if err != nil {
+		dialog.ShowInformation("Error!", err.Error(), p.GUI.Window)
+		return
+	}
+
+	if value.IsScalar() {
+		p.MatrixResult, _ = cmatrix.NewMatrix([][]float64{{value.Scalar}}, false)
+		p.ActionsAnswer.SetText(fmt.Sprint(value.Scalar))
+	} else {
+		p.MatrixResult = value.Matrix
+		p.ActionsAnswer.SetText(fmt.Sprintf("%dx%d matrix", value.Matrix.Rows, value.Matrix.Cols))
+	}
+
+	p.TableResult.Refresh()
+}
@@ -0,0 +1,194 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	cmatrix "github.com/shimeoki/mlat/internal/matrix"
+)
+
+const (
+	closureTransitive          = "Transitive (Warshall)"
+	closureReflexive           = "Reflexive"
+	closureSymmetric           = "Symmetric"
+	closureReflexiveTransitive = "Reflexive and transitive"
+	closureEquivalence         = "Equivalence"
+	closurePower               = "Boolean power"
+)
+
+type ClosureTab struct {
+	Size binding.Int
+
+	Matrix         *cmatrix.Matrix
+	Table          *widget.Table
+	TableContainer *fyne.Container
+
+	MatrixResult         *cmatrix.Matrix
+	TableResult          *widget.Table
+	TableResultContainer *fyne.Container
+
+	ActionsSize         *widget.Entry
+	ActionsClosure      *widget.Select
+	ActionsPower        *widget.Entry
+	ActionsImport       *widget.Button
+	ActionsImportDialog *dialog.FileDialog
+	ActionsCalculate    *widget.Button
+	ActionsAnswer       *widget.Label
+	ActionsContainer    *fyne.Container
+
+	MainContainer *fyne.Container
+
+	GUI *GUI
+}
+
+func (p *GUI) newClosureTab() *ClosureTab {
+	tab := &ClosureTab{}
+	tab.GUI = p
+
+	tab.Size = binding.NewInt()
+	tab.Size.Set(1)
+
+	tab.Matrix, _ = cmatrix.NewBlankMatrix(1, 1, false)
+	tab.MatrixResult, _ = cmatrix.NewBlankMatrix(1, 1, false)
+
+	tab.Table = createTable(&tab.Matrix)
+	tab.TableResult = createTable(&tab.MatrixResult)
+	tab.TableContainer = createTitledTable("Relation", tab.Table)
+	tab.TableResultContainer = createTitledTable("Closure", tab.TableResult)
+
+	tab.ActionsSize = createSizeEntry(tab.Size, func(size int) {
+		tab.Matrix.Resize(size, size)
+		tab.Table.Refresh()
+	})
+
+	tab.ActionsPower = widget.NewEntry()
+	tab.ActionsPower.SetText("2")
+	tab.ActionsPower.Validator = func(s string) error {
+		k, err := strconv.Atoi(s)
+		if err != nil {
+			return err
+		}
+		if k < 0 {
+			return errors.New("error: power is negative")
+		}
+		return nil
+	}
+	tab.ActionsPower.Disable()
+
+	tab.ActionsClosure = widget.NewSelect(
+		[]string{
+			closureTransitive,
+			closureReflexive,
+			closureSymmetric,
+			closureReflexiveTransitive,
+			closureEquivalence,
+			closurePower,
+		},
+		func(closure string) {
+			if closure == closurePower {
+				tab.ActionsPower.Enable()
+			} else {
+				tab.ActionsPower.Disable()
+			}
+		},
+	)
+	tab.ActionsClosure.SetSelectedIndex(0)
+
+	tab.ActionsImportDialog = newMatrixOpenDialog(tab.GUI.Window, func(data [][]float64) {
+		matrix, _ := cmatrix.NewMatrix(data, false)
+		if matrix.Rows != matrix.Cols {
+			dialog.ShowInformation("Error!", "matrix is not a square", tab.GUI.Window)
+			return
+		}
+
+		tab.Matrix = matrix
+		tab.Size.Set(matrix.Rows)
+		tab.Table.Refresh()
+	})
+	tab.ActionsImport = widget.NewButtonWithIcon(
+		"Import Relation",
+		theme.UploadIcon(),
+		func() {
+			tab.ActionsImportDialog.Show()
+		},
+	)
+
+	tab.ActionsAnswer = widget.NewLabel("")
+
+	tab.ActionsCalculate = widget.NewButtonWithIcon(
+		"Calculate",
+		theme.GridIcon(),
+		func() {
+			closure, err := tab.calculate()
+			if err != nil {
+				dialog.ShowInformation("Error!", err.Error(), tab.GUI.Window)
+				return
+			}
+
+			tab.MatrixResult, _ = cmatrix.NewMatrix(closure.Data(), false)
+			tab.TableResult.Refresh()
+			tab.ActionsAnswer.SetText(fmt.Sprintf("Pairs in the result: %d", closure.Count()))
+		},
+	)
+
+	tab.ActionsContainer = container.NewPadded(container.NewVBox(
+		createLabeledEntry("Size: ", tab.ActionsSize),
+		container.NewPadded(tab.ActionsClosure),
+		createLabeledEntry("Power: ", tab.ActionsPower),
+		container.NewPadded(tab.ActionsImport),
+		container.NewPadded(tab.ActionsCalculate),
+		tab.ActionsAnswer,
+	))
+
+	tab.MainContainer = container.NewBorder(
+		nil, nil, tab.ActionsContainer, nil,
+		container.NewAdaptiveGrid(2, tab.TableContainer, tab.TableResultContainer),
+	)
+
+	return tab
+}
+
+func (p *ClosureTab) calculate() (*cmatrix.BoolMatrix, error) {
+	relation, err := cmatrix.NewBoolMatrixFromData(p.Matrix.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	switch p.ActionsClosure.Selected {
+	case closureTransitive:
+		return relation.TransitiveClosure()
+	case closureReflexive:
+		return relation.ReflexiveClosure()
+	case closureSymmetric:
+		return relation.SymmetricClosure()
+	case closureReflexiveTransitive:
+		reflexive, err := relation.ReflexiveClosure()
+		if err != nil {
+			return nil, err
+		}
+		return reflexive.TransitiveClosure()
+	case closureEquivalence:
+		reflexive, err := relation.ReflexiveClosure()
+		if err != nil {
+			return nil, err
+		}
+		symmetric, _ := reflexive.SymmetricClosure()
+		return symmetric.TransitiveClosure()
+	case closurePower:
+		if err := p.ActionsPower.Validate(); err != nil {
+			return nil, err
+		}
+		k, _ := strconv.Atoi(p.ActionsPower.Text)
+		return relation.Power(k)
+	default:
+		return nil, errors.New("closure is not selected")
+	}
+}
@@ -0,0 +1,308 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/shimeoki/mlat/internal/graph"
+	cmatrix "github.com/shimeoki/mlat/internal/matrix"
+)
+
+const (
+	graphFloydWarshall = "Shortest paths (Floyd–Warshall)"
+	graphDijkstra      = "Shortest paths (Dijkstra)"
+	graphBFS           = "Breadth-first search"
+	graphDFS           = "Depth-first search"
+	graphComponents    = "Connected components"
+	graphStrong        = "Strongly connected components"
+	graphSpanningTree  = "Minimum spanning tree"
+	graphTopological   = "Topological sort"
+	graphCountPaths    = "Count paths"
+)
+
+type GraphTab struct {
+	Size binding.Int
+
+	Matrix         *cmatrix.Matrix
+	Table          *widget.Table
+	TableContainer *fyne.Container
+
+	MatrixResult         *cmatrix.Matrix
+	TableResult          *widget.Table
+	TableResultContainer *fyne.Container
+
+	ActionsSize         *widget.Entry
+	ActionsAlgorithm    *widget.Select
+	ActionsSource       *widget.Entry
+	ActionsLength       *widget.Entry
+	ActionsImport       *widget.Button
+	ActionsImportDialog *dialog.FileDialog
+	ActionsCalculate    *widget.Button
+	ActionsContainer    *fyne.Container
+
+	Report          *widget.Label
+	ReportContainer *fyne.Container
+
+	MainContainer *fyne.Container
+
+	GUI *GUI
+}
+
+func (p *GUI) newGraphTab() *GraphTab {
+	tab := &GraphTab{}
+	tab.GUI = p
+
+	tab.Size = binding.NewInt()
+	tab.Size.Set(1)
+
+	tab.Matrix, _ = cmatrix.NewBlankMatrix(1, 1, false)
+	tab.MatrixResult, _ = cmatrix.NewBlankMatrix(1, 1, false)
+
+	tab.Table = createTable(&tab.Matrix)
+	tab.TableResult = createTable(&tab.MatrixResult)
+	tab.TableContainer = createTitledTable("Weights", tab.Table)
+	tab.TableResultContainer = createTitledTable("Result", tab.TableResult)
+
+	tab.ActionsSize = createSizeEntry(tab.Size, func(size int) {
+		tab.Matrix.Resize(size, size)
+		tab.Table.Refresh()
+	})
+
+	tab.ActionsSource = widget.NewEntry()
+	tab.ActionsSource.SetText("1")
+	tab.ActionsSource.Validator = func(s string) error {
+		v, err := strconv.Atoi(s)
+		if err != nil {
+			return err
+		}
+		if v < 1 || v > tab.Matrix.Rows {
+			return fmt.Errorf("vertex %d does not exist", v)
+		}
+		return nil
+	}
+
+	tab.ActionsLength = widget.NewEntry()
+	tab.ActionsLength.SetText("2")
+	tab.ActionsLength.Validator = func(s string) error {
+		k, err := strconv.Atoi(s)
+		if err != nil {
+			return err
+		}
+		if k < 0 {
+			return errors.New("error: length is negative")
+		}
+		if k > graph.MaxPathLength {
+			return fmt.Errorf("error: length should be at most %d", graph.MaxPathLength)
+		}
+		return nil
+	}
+	tab.ActionsLength.Disable()
+
+	tab.ActionsAlgorithm = widget.NewSelect(
+		[]string{
+			graphFloydWarshall,
+			graphDijkstra,
+			graphBFS,
+			graphDFS,
+			graphComponents,
+			graphStrong,
+			graphSpanningTree,
+			graphTopological,
+			graphCountPaths,
+		},
+		func(algorithm string) {
+			if algorithm == graphCountPaths {
+				tab.ActionsLength.Enable()
+			} else {
+				tab.ActionsLength.Disable()
+			}
+		},
+	)
+	tab.ActionsAlgorithm.SetSelectedIndex(0)
+
+	tab.ActionsImportDialog = newMatrixOpenDialog(tab.GUI.Window, func(data [][]float64) {
+		matrix, _ := cmatrix.NewMatrix(data, false)
+		if matrix.Rows != matrix.Cols {
+			dialog.ShowInformation("Error!", "matrix is not a square", tab.GUI.Window)
+			return
+		}
+
+		tab.Matrix = matrix
+		tab.Size.Set(matrix.Rows)
+		tab.Table.Refresh()
+	})
+	tab.ActionsImport = widget.NewButtonWithIcon(
+		"Import Graph",
+		theme.UploadIcon(),
+		func() {
+			tab.ActionsImportDialog.Show()
+		},
+	)
+
+	tab.Report = widget.NewLabel("")
+	tab.Report.TextStyle.Monospace = true
+	tab.ReportContainer = container.NewPadded(container.NewBorder(
+		container.NewCenter(widget.NewLabelWithStyle(
+			"Report", fyne.TextAlignCenter, fyne.TextStyle{Bold: true})),
+		nil, nil, nil, container.NewScroll(tab.Report)))
+
+	tab.ActionsCalculate = widget.NewButtonWithIcon(
+		"Calculate",
+		theme.GridIcon(),
+		func() {
+			result, report, err := tab.calculate()
+			if err != nil {
+				dialog.ShowInformation("Error!", err.Error(), tab.GUI.Window)
+				return
+			}
+
+			if result == nil {
+				tab.MatrixResult, _ = cmatrix.NewBlankMatrix(1, 1, false)
+			} else {
+				tab.MatrixResult, _ = cmatrix.NewMatrix(result, false)
+			}
+			tab.TableResult.Refresh()
+			tab.Report.SetText(report)
+		},
+	)
+
+	tab.ActionsContainer = container.NewPadded(container.NewVBox(
+		createLabeledEntry("Size: ", tab.ActionsSize),
+		container.NewPadded(tab.ActionsAlgorithm),
+		createLabeledEntry("Source: ", tab.ActionsSource),
+		createLabeledEntry("Length: ", tab.ActionsLength),
+		container.NewPadded(tab.ActionsImport),
+		container.NewPadded(tab.ActionsCalculate),
+	))
+
+	tab.MainContainer = container.NewBorder(
+		nil, nil, tab.ActionsContainer, nil,
+		container.NewAdaptiveGrid(2,
+			tab.TableContainer,
+			container.NewVSplit(tab.TableResultContainer, tab.ReportContainer),
+		),
+	)
+
+	return tab
+}
+
+// vertices are shown counted from 1
+func formatVertices(vertices []int, separator string) string {
+	names := make([]string, len(vertices))
+	for i, v := range vertices {
+		names[i] = strconv.Itoa(v + 1)
+	}
+	return strings.Join(names, separator)
+}
+
+func formatPath(sb *strings.Builder, source, target int, dist float64, path []int) {
+	if path == nil {
+		fmt.Fprintf(sb, "%d -> %d: unreachable\n", source+1, target+1)
+		return
+	}
+	fmt.Fprintf(sb, "%d -> %d (%v): %s\n", source+1, target+1, dist, formatVertices(path, " -> "))
+}
+
+// returns matrix for the result table (nil if there is none) and text report
+func (p *GraphTab) calculate() ([][]float64, string, error) {
+	g, err := graph.New(p.Matrix)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := p.ActionsSource.Validate(); err != nil {
+		return nil, "", err
+	}
+	source, _ := strconv.Atoi(p.ActionsSource.Text)
+	source--
+
+	var sb strings.Builder
+
+	switch p.ActionsAlgorithm.Selected {
+	case graphFloydWarshall:
+		paths, err := g.FloydWarshall()
+		if err != nil {
+			return nil, "", err
+		}
+		for v := range g.Size {
+			formatPath(&sb, source, v, paths.Dist[source][v], paths.Path(source, v))
+		}
+		return paths.Dist, sb.String(), nil
+	case graphDijkstra:
+		paths, err := g.Dijkstra(source)
+		if err != nil {
+			return nil, "", err
+		}
+		for v := range g.Size {
+			formatPath(&sb, source, v, paths.Dist[v], paths.Path(v))
+		}
+		return [][]float64{paths.Dist}, sb.String(), nil
+	case graphBFS, graphDFS:
+		search := g.BFS
+		if p.ActionsAlgorithm.Selected == graphDFS {
+			search = g.DFS
+		}
+		order, err := search(source)
+		if err != nil {
+			return nil, "", err
+		}
+		return nil, "order: " + formatVertices(order, ", ") + "\n", nil
+	case graphComponents, graphStrong:
+		components := g.Components()
+		if p.ActionsAlgorithm.Selected == graphStrong {
+			components = g.StronglyConnectedComponents()
+		}
+		fmt.Fprintf(&sb, "components: %d\n", len(components))
+		for _, component := range components {
+			fmt.Fprintf(&sb, "{%s}\n", formatVertices(component, ", "))
+		}
+		return nil, sb.String(), nil
+	case graphSpanningTree:
+		edges, total, err := g.MinimumSpanningTree()
+		if err != nil {
+			return nil, "", err
+		}
+		tree, _ := cmatrix.NewBlankMatrix(g.Size, g.Size, false)
+		fmt.Fprintf(&sb, "weight: %v\n", total)
+		for _, edge := range edges {
+			u, v := edge[0], edge[1]
+			tree.Data[u][v], tree.Data[v][u] = g.Weights[u][v], g.Weights[u][v]
+			fmt.Fprintf(&sb, "%d - %d (%v)\n", u+1, v+1, g.Weights[u][v])
+		}
+		return tree.Data, sb.String(), nil
+	case graphTopological:
+		order, err := g.TopologicalSort()
+		if err != nil {
+			return nil, "", err
+		}
+		return nil, "order: " + formatVertices(order, ", ") + "\n", nil
+	case graphCountPaths:
+		if err := p.ActionsLength.Validate(); err != nil {
+			return nil, "", err
+		}
+		length, _ := strconv.Atoi(p.ActionsLength.Text)
+		counts, err := g.CountPaths(length)
+		if err != nil {
+			return nil, "", err
+		}
+		total := 0.0
+		for _, row := range counts {
+			for _, c := range row {
+				total += c
+			}
+		}
+		fmt.Fprintf(&sb, "paths of length %d: %v\n", length, total)
+		return counts, sb.String(), nil
+	default:
+		return nil, "", errors.New("algorithm is not selected")
+	}
+}
@@ -0,0 +1,344 @@
+package ui
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	cmatrix "github.com/shimeoki/mlat/internal/matrix"
+)
+
+const (
+	operationAdd        = "A + B"
+	operationSubtract   = "A - B"
+	operationScale      = "k · A"
+	operationHadamard   = "A ∘ B"
+	operationKronecker  = "A ⊗ B"
+	operationHorizontal = "[A B]"
+	operationVertical   = "[A; B]"
+)
+
+type OperationsTab struct {
+	RowsA binding.Int
+	ColsA binding.Int
+	RowsB binding.Int
+	ColsB binding.Int
+
+	MatrixA         *cmatrix.Matrix
+	TableA          *widget.Table
+	TableAContainer *fyne.Container
+
+	MatrixB         *cmatrix.Matrix
+	TableB          *widget.Table
+	TableBContainer *fyne.Container
+
+	MatrixResult         *cmatrix.Matrix
+	TableResult          *widget.Table
+	TableResultContainer *fyne.Container
+
+	ActionsRowsA *widget.Entry
+	ActionsColsA *widget.Entry
+	ActionsRowsB *widget.Entry
+	ActionsColsB *widget.Entry
+
+	ActionsOperation *widget.Select
+	ActionsScalar    *widget.Entry
+
+	ActionsImportA       *widget.Button
+	ActionsImportADialog *dialog.FileDialog
+	ActionsImportB       *widget.Button
+	ActionsImportBDialog *dialog.FileDialog
+	ActionsCalculate     *widget.Button
+
+	ActionsContainer *fyne.Container
+
+	MainContainer *fyne.Container
+
+	GUI *GUI
+}
+
+func validateSize(s string) error {
+	value, err := strconv.Atoi(s)
+	if err != nil {
+		return err
+	}
+
+	if value <= 0 || value >= 100 {
+		return errors.New("error: value is not in range (0, 100]")
+	}
+
+	return nil
+}
+
+func createTitledTable(title string, table *widget.Table) *fyne.Container {
+	return container.NewPadded(container.NewBorder(
+		container.NewCenter(widget.NewLabelWithStyle(
+			title, fyne.TextAlignCenter, fyne.TextStyle{Bold: true})), nil, nil, nil, table))
+}
+
+func createLabeledEntry(label string, entry *widget.Entry) *fyne.Container {
+	return container.NewGridWithColumns(2,
+		container.NewBorder(nil, nil, nil, container.NewCenter(
+			widget.NewLabelWithStyle(label, fyne.TextAlignTrailing, fyne.TextStyle{Monospace: true}))),
+		container.NewPadded(entry))
+}
+
+func createSizeEntry(value binding.Int, onChanged func(int)) *widget.Entry {
+	entry := widget.NewEntryWithData(binding.IntToString(value))
+	entry.Validator = validateSize
+	entry.OnChanged = func(s string) {
+		if entry.Validate() != nil {
+			return
+		}
+
+		size, _ := strconv.Atoi(s)
+		onChanged(size)
+	}
+
+	return entry
+}
+
+// reads matrix like cmatrix.ReadFile, but for .npz archives with several
+// matrices shows the dialog to choose one of them by name
+func readMatrixFile(window fyne.Window, uri fyne.URIReadCloser, callback func(matrix *cmatrix.Matrix)) {
+	if strings.ToLower(uri.URI().Extension()) != ".npz" {
+		matrix, err := cmatrix.ReadFile(uri.URI().Name(), uri)
+		if err != nil {
+			dialog.ShowInformation("Error!", err.Error(), window)
+			return
+		}
+
+		callback(matrix)
+		return
+	}
+
+	matrices, err := cmatrix.ReadNPZ(uri)
+	if err != nil {
+		dialog.ShowInformation("Error!", err.Error(), window)
+		return
+	}
+
+	names := make([]string, 0, len(matrices))
+	for name := range matrices {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	choose := func(name string) {
+		matrix, err := cmatrix.NewMatrix(matrices[name], false)
+		if err != nil {
+			dialog.ShowInformation("Error!", err.Error(), window)
+			return
+		}
+
+		matrix.Name = name
+		callback(matrix)
+	}
+
+	if len(names) == 1 {
+		choose(names[0])
+		return
+	}
+
+	selectName := widget.NewSelect(names, nil)
+	selectName.SetSelectedIndex(0)
+	dialog.ShowForm(
+		"Choose matrix",
+		"Import",
+		"Cancel",
+		[]*widget.FormItem{widget.NewFormItem("Name", selectName)},
+		func(confirmed bool) {
+			if confirmed {
+				choose(selectName.Selected)
+			}
+		},
+		window,
+	)
+}
+
+func newMatrixOpenDialog(window fyne.Window, callback func(data [][]float64)) *dialog.FileDialog {
+	return dialog.NewFileOpen(
+		func(uri fyne.URIReadCloser, err error) {
+			if uri == nil || err != nil {
+				return
+			}
+			defer uri.Close()
+
+			readMatrixFile(window, uri, func(matrix *cmatrix.Matrix) {
+				callback(matrix.Data)
+			})
+		},
+		window,
+	)
+}
+
+func (p *GUI) newOperationsTab() *OperationsTab {
+	tab := &OperationsTab{}
+	tab.GUI = p
+
+	tab.RowsA = binding.NewInt()
+	tab.RowsA.Set(1)
+	tab.ColsA = binding.NewInt()
+	tab.ColsA.Set(1)
+	tab.RowsB = binding.NewInt()
+	tab.RowsB.Set(1)
+	tab.ColsB = binding.NewInt()
+	tab.ColsB.Set(1)
+
+	tab.MatrixA, _ = cmatrix.NewBlankMatrix(1, 1, false)
+	tab.MatrixB, _ = cmatrix.NewBlankMatrix(1, 1, false)
+	tab.MatrixResult, _ = cmatrix.NewBlankMatrix(1, 1, false)
+
+	tab.TableA = createTable(&tab.MatrixA)
+	tab.TableB = createTable(&tab.MatrixB)
+	tab.TableResult = createTable(&tab.MatrixResult)
+
+	tab.TableAContainer = createTitledTable("Matrix A", tab.TableA)
+	tab.TableBContainer = createTitledTable("Matrix B", tab.TableB)
+	tab.TableResultContainer = createTitledTable("Result", tab.TableResult)
+
+	tab.ActionsRowsA = createSizeEntry(tab.RowsA, func(rows int) {
+		tab.MatrixA.ResizeRows(rows)
+		tab.TableA.Refresh()
+	})
+	tab.ActionsColsA = createSizeEntry(tab.ColsA, func(cols int) {
+		tab.MatrixA.ResizeCols(cols)
+		tab.TableA.Refresh()
+	})
+	tab.ActionsRowsB = createSizeEntry(tab.RowsB, func(rows int) {
+		tab.MatrixB.ResizeRows(rows)
+		tab.TableB.Refresh()
+	})
+	tab.ActionsColsB = createSizeEntry(tab.ColsB, func(cols int) {
+		tab.MatrixB.ResizeCols(cols)
+		tab.TableB.Refresh()
+	})
+
+	tab.ActionsScalar = widget.NewEntry()
+	tab.ActionsScalar.SetText("1")
+	tab.ActionsScalar.Validator = func(s string) error {
+		_, err := strconv.ParseFloat(s, 64)
+		return err
+	}
+	tab.ActionsScalar.Disable()
+
+	tab.ActionsOperation = widget.NewSelect(
+		[]string{
+			operationAdd,
+			operationSubtract,
+			operationScale,
+			operationHadamard,
+			operationKronecker,
+			operationHorizontal,
+			operationVertical,
+		},
+		func(operation string) {
+			if operation == operationScale {
+				tab.ActionsScalar.Enable()
+			} else {
+				tab.ActionsScalar.Disable()
+			}
+		},
+	)
+	tab.ActionsOperation.SetSelectedIndex(0)
+
+	tab.ActionsImportADialog = newMatrixOpenDialog(tab.GUI.Window, func(data [][]float64) {
+		tab.MatrixA, _ = cmatrix.NewMatrix(data, false)
+		tab.RowsA.Set(tab.MatrixA.Rows)
+		tab.ColsA.Set(tab.MatrixA.Cols)
+		tab.TableA.Refresh()
+	})
+	tab.ActionsImportA = widget.NewButtonWithIcon(
+		"Import Matrix A",
+		theme.UploadIcon(),
+		func() {
+			tab.ActionsImportADialog.Show()
+		},
+	)
+
+	tab.ActionsImportBDialog = newMatrixOpenDialog(tab.GUI.Window, func(data [][]float64) {
+		tab.MatrixB, _ = cmatrix.NewMatrix(data, false)
+		tab.RowsB.Set(tab.MatrixB.Rows)
+		tab.ColsB.Set(tab.MatrixB.Cols)
+		tab.TableB.Refresh()
+	})
+	tab.ActionsImportB = widget.NewButtonWithIcon(
+		"Import Matrix B",
+		theme.UploadIcon(),
+		func() {
+			tab.ActionsImportBDialog.Show()
+		},
+	)
+
+	tab.ActionsCalculate = widget.NewButtonWithIcon(
+		"Calculate",
+		theme.GridIcon(),
+		func() {
+			data, err := tab.calculate()
+			if err != nil {
+				dialog.ShowInformation("Error!", err.Error(), tab.GUI.Window)
+				return
+			}
+
+			tab.MatrixResult, _ = cmatrix.NewMatrix(data, false)
+			tab.TableResult.Refresh()
+		},
+	)
+
+	tab.ActionsContainer = container.NewPadded(container.NewHBox(
+		container.NewVBox(
+			createLabeledEntry("A rows: ", tab.ActionsRowsA),
+			createLabeledEntry("A cols: ", tab.ActionsColsA),
+			createLabeledEntry("B rows: ", tab.ActionsRowsB),
+			createLabeledEntry("B cols: ", tab.ActionsColsB),
+		), container.NewVBox(
+			container.NewPadded(tab.ActionsOperation),
+			createLabeledEntry("k: ", tab.ActionsScalar),
+			container.NewPadded(tab.ActionsImportA),
+			container.NewPadded(tab.ActionsImportB),
+			container.NewPadded(tab.ActionsCalculate),
+		)))
+
+	tab.MainContainer = container.NewAdaptiveGrid(
+		2,
+		tab.TableAContainer,
+		tab.TableBContainer,
+		tab.TableResultContainer,
+		tab.ActionsContainer,
+	)
+
+	return tab
+}
+
+func (p *OperationsTab) calculate() ([][]float64, error) {
+	switch p.ActionsOperation.Selected {
+	case operationAdd:
+		return p.MatrixA.Add(p.MatrixB)
+	case operationSubtract:
+		return p.MatrixA.Subtract(p.MatrixB)
+	case operationScale:
+		scalar, err := strconv.ParseFloat(p.ActionsScalar.Text, 64)
+		if err != nil {
+			return nil, errors.New("scalar k is not a number")
+		}
+		return p.MatrixA.Scale(scalar), nil
+	case operationHadamard:
+		return p.MatrixA.Hadamard(p.MatrixB)
+	case operationKronecker:
+		return p.MatrixA.Kronecker(p.MatrixB), nil
+	case operationHorizontal:
+		return p.MatrixA.ConcatHorizontal(p.MatrixB)
+	case operationVertical:
+		return p.MatrixA.ConcatVertical(p.MatrixB)
+	default:
+		return nil, errors.New("operation is not selected")
+	}
+}
@@ -0,0 +1,250 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	cmatrix "github.com/shimeoki/mlat/internal/matrix"
+)
+
+const (
+	orthogonalClassical  = "Gram–Schmidt (classical)"
+	orthogonalModified   = "Gram–Schmidt (modified)"
+	orthogonalProject    = "Project vector"
+	orthogonalComplement = "Orthogonal complement"
+
+	vectorsColumns = "Columns"
+	vectorsRows    = "Rows"
+)
+
+type OrthogonalTab struct {
+	Rows binding.Int
+	Cols binding.Int
+
+	Matrix         *cmatrix.Matrix
+	Table          *widget.Table
+	TableContainer *fyne.Container
+
+	MatrixResult         *cmatrix.Matrix
+	TableResult          *widget.Table
+	TableResultContainer *fyne.Container
+
+	ActionsRows         *widget.Entry
+	ActionsCols         *widget.Entry
+	ActionsOperation    *widget.Select
+	ActionsVectors      *widget.Select
+	ActionsVector       *widget.Entry
+	ActionsImport       *widget.Button
+	ActionsImportDialog *dialog.FileDialog
+	ActionsCalculate    *widget.Button
+	ActionsContainer    *fyne.Container
+
+	Report          *widget.Label
+	ReportContainer *fyne.Container
+
+	MainContainer *fyne.Container
+
+	GUI *GUI
+}
+
+func (p *GUI) newOrthogonalTab() *OrthogonalTab {
+	tab := &OrthogonalTab{}
+	tab.GUI = p
+
+	tab.Rows = binding.NewInt()
+	tab.Rows.Set(1)
+	tab.Cols = binding.NewInt()
+	tab.Cols.Set(1)
+
+	tab.Matrix, _ = cmatrix.NewBlankMatrix(1, 1, false)
+	tab.MatrixResult, _ = cmatrix.NewBlankMatrix(1, 1, false)
+
+	tab.Table = createTable(&tab.Matrix)
+	tab.TableResult = createTable(&tab.MatrixResult)
+	tab.TableContainer = createTitledTable("Vectors", tab.Table)
+	tab.TableResultContainer = createTitledTable("Result", tab.TableResult)
+
+	tab.ActionsRows = createSizeEntry(tab.Rows, func(rows int) {
+		tab.Matrix.ResizeRows(rows)
+		tab.Table.Refresh()
+	})
+	tab.ActionsCols = createSizeEntry(tab.Cols, func(cols int) {
+		tab.Matrix.ResizeCols(cols)
+		tab.Table.Refresh()
+	})
+
+	tab.ActionsVector = widget.NewEntry()
+	tab.ActionsVector.SetPlaceHolder("1 2 3")
+	tab.ActionsVector.Disable()
+
+	tab.ActionsOperation = widget.NewSelect(
+		[]string{
+			orthogonalClassical,
+			orthogonalModified,
+			orthogonalProject,
+			orthogonalComplement,
+		},
+		func(operation string) {
+			if operation == orthogonalProject {
+				tab.ActionsVector.Enable()
+			} else {
+				tab.ActionsVector.Disable()
+			}
+		},
+	)
+	tab.ActionsOperation.SetSelectedIndex(0)
+
+	tab.ActionsVectors = widget.NewSelect(
+		[]string{
+			vectorsColumns,
+			vectorsRows,
+		},
+		nil,
+	)
+	tab.ActionsVectors.SetSelectedIndex(0)
+
+	tab.ActionsImportDialog = newMatrixOpenDialog(tab.GUI.Window, func(data [][]float64) {
+		tab.Matrix, _ = cmatrix.NewMatrix(data, false)
+		tab.Rows.Set(tab.Matrix.Rows)
+		tab.Cols.Set(tab.Matrix.Cols)
+		tab.Table.Refresh()
+	})
+	tab.ActionsImport = widget.NewButtonWithIcon(
+		"Import Matrix",
+		theme.UploadIcon(),
+		func() {
+			tab.ActionsImportDialog.Show()
+		},
+	)
+
+	tab.Report = widget.NewLabel("")
+	tab.Report.TextStyle.Monospace = true
+	tab.ReportContainer = container.NewPadded(container.NewBorder(
+		container.NewCenter(widget.NewLabelWithStyle(
+			"Report", fyne.TextAlignCenter, fyne.TextStyle{Bold: true})),
+		nil, nil, nil, container.NewScroll(tab.Report)))
+
+	tab.ActionsCalculate = widget.NewButtonWithIcon(
+		"Calculate",
+		theme.GridIcon(),
+		func() {
+			result, report, err := tab.calculate()
+			if err != nil {
+				dialog.ShowInformation("Error!", err.Error(), tab.GUI.Window)
+				return
+			}
+
+			if result == nil {
+				tab.MatrixResult, _ = cmatrix.NewBlankMatrix(1, 1, false)
+			} else {
+				tab.MatrixResult, _ = cmatrix.NewMatrix(result, false)
+			}
+			tab.TableResult.Refresh()
+			tab.Report.SetText(report)
+		},
+	)
+
+	tab.ActionsContainer = container.NewPadded(container.NewVBox(
+		createLabeledEntry("Rows: ", tab.ActionsRows),
+		createLabeledEntry("Cols: ", tab.ActionsCols),
+		container.NewPadded(tab.ActionsOperation),
+		container.NewPadded(tab.ActionsVectors),
+		createLabeledEntry("Vector: ", tab.ActionsVector),
+		container.NewPadded(tab.ActionsImport),
+		container.NewPadded(tab.ActionsCalculate),
+	))
+
+	tab.MainContainer = container.NewBorder(
+		nil, nil, tab.ActionsContainer, nil,
+		container.NewAdaptiveGrid(2,
+			tab.TableContainer,
+			container.NewVSplit(tab.TableResultContainer, tab.ReportContainer),
+		),
+	)
+
+	return tab
+}
+
+// parses values separated by spaces
+func parseVector(s string) ([]float64, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return nil, errors.New("vector is empty")
+	}
+
+	vector := make([]float64, len(fields))
+	for i, field := range fields {
+		value, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return nil, fmt.Errorf("value %q is not a number", field)
+		}
+		vector[i] = value
+	}
+
+	return vector, nil
+}
+
+// returns matrix for the result table (nil if there is none) and text report
+func (p *OrthogonalTab) calculate() ([][]float64, string, error) {
+	byRows := p.ActionsVectors.Selected == vectorsRows
+
+	switch p.ActionsOperation.Selected {
+	case orthogonalClassical, orthogonalModified:
+		basis := p.Matrix.GramSchmidt(byRows, p.ActionsOperation.Selected == orthogonalModified)
+
+		var sb strings.Builder
+		if len(basis.Dependent) != 0 {
+			fmt.Fprintf(&sb, "dependent vectors: %s\n", formatVertices(basis.Dependent, ", "))
+		} else {
+			sb.WriteString("vectors are independent\n")
+		}
+		sb.WriteString("orthogonal basis:\n")
+		sb.WriteString(cmatrix.MatrixToString(basis.Orthogonal, " "))
+
+		return basis.Orthonormal, sb.String(), nil
+	case orthogonalProject:
+		vector, err := parseVector(p.ActionsVector.Text)
+		if err != nil {
+			return nil, "", err
+		}
+
+		projection, err := p.Matrix.Project(vector, byRows)
+		if err != nil {
+			return nil, "", err
+		}
+
+		perpendicular := make([]float64, len(vector))
+		for i := range vector {
+			perpendicular[i] = vector[i] - projection[i]
+		}
+
+		report := fmt.Sprintf(
+			"projection: %s\nperpendicular: %s\n",
+			cmatrix.ArrayToString(projection, " "),
+			cmatrix.ArrayToString(perpendicular, " "),
+		)
+		return [][]float64{projection}, report, nil
+	case orthogonalComplement:
+		complement := p.Matrix.OrthogonalComplement(byRows)
+		if complement == nil {
+			return nil, "vectors span the whole space\n", nil
+		}
+
+		dimension := len(complement)
+		if !byRows {
+			dimension = len(complement[0])
+		}
+		return complement, fmt.Sprintf("dimension: %d\n", dimension), nil
+	default:
+		return nil, "", errors.New("operation is not selected")
+	}
+}
@@ -0,0 +1,162 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	cmatrix "github.com/shimeoki/mlat/internal/matrix"
+)
+
+const (
+	quadraticLagrange   = "Lagrange method"
+	quadraticOrthogonal = "Orthogonal transformation"
+)
+
+type QuadraticTab struct {
+	Size binding.Int
+
+	Matrix         *cmatrix.Matrix
+	Table          *widget.Table
+	TableContainer *fyne.Container
+
+	MatrixResult         *cmatrix.Matrix
+	TableResult          *widget.Table
+	TableResultContainer *fyne.Container
+
+	ActionsSize         *widget.Entry
+	ActionsMethod       *widget.Select
+	ActionsImport       *widget.Button
+	ActionsImportDialog *dialog.FileDialog
+	ActionsCalculate    *widget.Button
+	ActionsContainer    *fyne.Container
+
+	Report          *widget.Label
+	ReportContainer *fyne.Container
+
+	MainContainer *fyne.Container
+
+	GUI *GUI
+}
+
+func (p *GUI) newQuadraticTab() *QuadraticTab {
+	tab := &QuadraticTab{}
+	tab.GUI = p
+
+	tab.Size = binding.NewInt()
+	tab.Size.Set(1)
+
+	tab.Matrix, _ = cmatrix.NewBlankMatrix(1, 1, false)
+	tab.MatrixResult, _ = cmatrix.NewBlankMatrix(1, 1, false)
+
+	tab.Table = createTable(&tab.Matrix)
+	tab.TableResult = createTable(&tab.MatrixResult)
+	tab.TableContainer = createTitledTable("Symmetric matrix", tab.Table)
+	tab.TableResultContainer = createTitledTable("Transformation", tab.TableResult)
+
+	tab.ActionsSize = createSizeEntry(tab.Size, func(size int) {
+		tab.Matrix.Resize(size, size)
+		tab.Table.Refresh()
+	})
+
+	tab.ActionsMethod = widget.NewSelect(
+		[]string{
+			quadraticLagrange,
+			quadraticOrthogonal,
+		},
+		nil,
+	)
+	tab.ActionsMethod.SetSelectedIndex(0)
+
+	tab.ActionsImportDialog = newMatrixOpenDialog(tab.GUI.Window, func(data [][]float64) {
+		matrix, _ := cmatrix.NewMatrix(data, false)
+		if matrix.Rows != matrix.Cols {
+			dialog.ShowInformation("Error!", "matrix is not a square", tab.GUI.Window)
+			return
+		}
+
+		tab.Matrix = matrix
+		tab.Size.Set(matrix.Rows)
+		tab.Table.Refresh()
+	})
+	tab.ActionsImport = widget.NewButtonWithIcon(
+		"Import Matrix",
+		theme.UploadIcon(),
+		func() {
+			tab.ActionsImportDialog.Show()
+		},
+	)
+
+	tab.Report = widget.NewLabel("")
+	tab.Report.TextStyle.Monospace = true
+	tab.ReportContainer = container.NewPadded(container.NewBorder(
+		container.NewCenter(widget.NewLabelWithStyle(
+			"Canonical form", fyne.TextAlignCenter, fyne.TextStyle{Bold: true})),
+		nil, nil, nil, container.NewScroll(tab.Report)))
+
+	tab.ActionsCalculate = widget.NewButtonWithIcon(
+		"Calculate",
+		theme.GridIcon(),
+		func() {
+			form, report, err := tab.calculate()
+			if err != nil {
+				dialog.ShowInformation("Error!", err.Error(), tab.GUI.Window)
+				return
+			}
+
+			tab.MatrixResult, _ = cmatrix.NewMatrix(form.Transformation, false)
+			tab.TableResult.Refresh()
+			tab.Report.SetText(report)
+		},
+	)
+
+	tab.ActionsContainer = container.NewPadded(container.NewVBox(
+		createLabeledEntry("Size: ", tab.ActionsSize),
+		container.NewPadded(tab.ActionsMethod),
+		container.NewPadded(tab.ActionsImport),
+		container.NewPadded(tab.ActionsCalculate),
+	))
+
+	tab.MainContainer = container.NewBorder(
+		nil, nil, tab.ActionsContainer, nil,
+		container.NewAdaptiveGrid(2,
+			tab.TableContainer,
+			container.NewVSplit(tab.TableResultContainer, tab.ReportContainer),
+		),
+	)
+
+	return tab
+}
+
+// returns canonical form with the selected method and text report
+func (p *QuadraticTab) calculate() (*cmatrix.CanonicalForm, string, error) {
+	var form *cmatrix.CanonicalForm
+	var err error
+
+	switch p.ActionsMethod.Selected {
+	case quadraticLagrange:
+		form, err = p.Matrix.Lagrange()
+	case quadraticOrthogonal:
+		form, err = p.Matrix.OrthogonalReduction()
+	default:
+		return nil, "", errors.New("method is not selected")
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	minors, _ := p.Matrix.LeadingMinors()
+	definiteness, _ := p.Matrix.Definiteness()
+
+	report := fmt.Sprintf(
+		"%sleading minors: %s\nform is %s\n",
+		form, cmatrix.ArrayToString(minors, " "), definiteness,
+	)
+	return form, report, nil
+}
@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	cmatrix "github.com/shimeoki/mlat/internal/matrix"
+	"github.com/shimeoki/mlat/internal/relation"
+)
+
+type RelationTab struct {
+	Size binding.Int
+
+	Matrix         *cmatrix.Matrix
+	Table          *widget.Table
+	TableContainer *fyne.Container
+
+	ActionsSize         *widget.Entry
+	ActionsImport       *widget.Button
+	ActionsImportDialog *dialog.FileDialog
+	ActionsAnalyze      *widget.Button
+	ActionsContainer    *fyne.Container
+
+	Report          *widget.Label
+	ReportContainer *fyne.Container
+
+	MainContainer *fyne.Container
+
+	GUI *GUI
+}
+
+func (p *GUI) newRelationTab() *RelationTab {
+	tab := &RelationTab{}
+	tab.GUI = p
+
+	tab.Size = binding.NewInt()
+	tab.Size.Set(1)
+
+	tab.Matrix, _ = cmatrix.NewBlankMatrix(1, 1, false)
+	tab.Table = createTable(&tab.Matrix)
+	tab.TableContainer = createTitledTable("Relation", tab.Table)
+
+	tab.ActionsSize = createSizeEntry(tab.Size, func(size int) {
+		tab.Matrix.Resize(size, size)
+		tab.Table.Refresh()
+	})
+
+	tab.ActionsImportDialog = newMatrixOpenDialog(tab.GUI.Window, func(data [][]float64) {
+		matrix, _ := cmatrix.NewMatrix(data, false)
+		if matrix.Rows != matrix.Cols {
+			dialog.ShowInformation("Error!", "matrix is not a square", tab.GUI.Window)
+			return
+		}
+
+		tab.Matrix = matrix
+		tab.Size.Set(matrix.Rows)
+		tab.Table.Refresh()
+	})
+	tab.ActionsImport = widget.NewButtonWithIcon(
+		"Import Relation",
+		theme.UploadIcon(),
+		func() {
+			tab.ActionsImportDialog.Show()
+		},
+	)
+
+	tab.Report = widget.NewLabel("")
+	tab.Report.TextStyle.Monospace = true
+	tab.ReportContainer = container.NewPadded(container.NewBorder(
+		container.NewCenter(widget.NewLabelWithStyle(
+			"Report", fyne.TextAlignCenter, fyne.TextStyle{Bold: true})),
+		nil, nil, nil, container.NewScroll(tab.Report)))
+
+	tab.ActionsAnalyze = widget.NewButtonWithIcon(
+		"Analyze",
+		theme.SearchIcon(),
+		func() {
+			report, err := relation.Analyze(tab.Matrix)
+			if err != nil {
+				dialog.ShowInformation("Error!", err.Error(), tab.GUI.Window)
+				return
+			}
+
+			tab.Report.SetText(report.String())
+		},
+	)
+
+	tab.ActionsContainer = container.NewPadded(container.NewVBox(
+		createLabeledEntry("Size: ", tab.ActionsSize),
+		container.NewPadded(tab.ActionsImport),
+		container.NewPadded(tab.ActionsAnalyze),
+	))
+
+	tab.MainContainer = container.NewBorder(
+		nil, nil, tab.ActionsContainer, nil,
+		container.NewAdaptiveGrid(2, tab.TableContainer, tab.ReportContainer),
+	)
+
+	return tab
+}
@@ -0,0 +1,240 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	cmatrix "github.com/shimeoki/mlat/internal/matrix"
+)
+
+const (
+	subspaceRREF        = "Reduced row echelon form"
+	subspaceNull        = "Null space"
+	subspaceColumn      = "Column space"
+	subspaceRow         = "Row space"
+	subspaceLeftNull    = "Left null space"
+	subspaceIndependent = "Linear independence"
+	subspaceTransition  = "Transition matrix (A → B)"
+)
+
+type SubspacesTab struct {
+	Rows binding.Int
+	Cols binding.Int
+
+	MatrixA         *cmatrix.Matrix
+	TableA          *widget.Table
+	TableAContainer *fyne.Container
+
+	MatrixB         *cmatrix.Matrix
+	TableB          *widget.Table
+	TableBContainer *fyne.Container
+
+	MatrixResult         *cmatrix.Matrix
+	TableResult          *widget.Table
+	TableResultContainer *fyne.Container
+
+	ActionsRows          *widget.Entry
+	ActionsCols          *widget.Entry
+	ActionsOperation     *widget.Select
+	ActionsVectors       *widget.Select
+	ActionsImportA       *widget.Button
+	ActionsImportADialog *dialog.FileDialog
+	ActionsImportB       *widget.Button
+	ActionsImportBDialog *dialog.FileDialog
+	ActionsCalculate     *widget.Button
+	ActionsContainer     *fyne.Container
+
+	Report          *widget.Label
+	ReportContainer *fyne.Container
+
+	MainContainer *fyne.Container
+
+	GUI *GUI
+}
+
+func (p *GUI) newSubspacesTab() *SubspacesTab {
+	tab := &SubspacesTab{}
+	tab.GUI = p
+
+	tab.Rows = binding.NewInt()
+	tab.Rows.Set(1)
+	tab.Cols = binding.NewInt()
+	tab.Cols.Set(1)
+
+	tab.MatrixA, _ = cmatrix.NewBlankMatrix(1, 1, false)
+	tab.MatrixB, _ = cmatrix.NewBlankMatrix(1, 1, false)
+	tab.MatrixResult, _ = cmatrix.NewBlankMatrix(1, 1, false)
+
+	tab.TableA = createTable(&tab.MatrixA)
+	tab.TableB = createTable(&tab.MatrixB)
+	tab.TableResult = createTable(&tab.MatrixResult)
+	tab.TableAContainer = createTitledTable("Matrix A", tab.TableA)
+	tab.TableBContainer = createTitledTable("Matrix B", tab.TableB)
+	tab.TableResultContainer = createTitledTable("Result", tab.TableResult)
+
+	// both matrices have the same size, B is used only for the transition matrix
+	tab.ActionsRows = createSizeEntry(tab.Rows, func(rows int) {
+		tab.MatrixA.ResizeRows(rows)
+		tab.MatrixB.ResizeRows(rows)
+		tab.TableA.Refresh()
+		tab.TableB.Refresh()
+	})
+	tab.ActionsCols = createSizeEntry(tab.Cols, func(cols int) {
+		tab.MatrixA.ResizeCols(cols)
+		tab.MatrixB.ResizeCols(cols)
+		tab.TableA.Refresh()
+		tab.TableB.Refresh()
+	})
+
+	tab.ActionsVectors = widget.NewSelect(
+		[]string{
+			vectorsColumns,
+			vectorsRows,
+		},
+		nil,
+	)
+	tab.ActionsVectors.SetSelectedIndex(0)
+	tab.ActionsVectors.Disable()
+
+	tab.ActionsOperation = widget.NewSelect(
+		[]string{
+			subspaceRREF,
+			subspaceNull,
+			subspaceColumn,
+			subspaceRow,
+			subspaceLeftNull,
+			subspaceIndependent,
+			subspaceTransition,
+		},
+		func(operation string) {
+			if operation == subspaceIndependent {
+				tab.ActionsVectors.Enable()
+			} else {
+				tab.ActionsVectors.Disable()
+			}
+		},
+	)
+	tab.ActionsOperation.SetSelectedIndex(0)
+
+	tab.ActionsImportADialog = newMatrixOpenDialog(tab.GUI.Window, func(data [][]float64) {
+		tab.MatrixA, _ = cmatrix.NewMatrix(data, false)
+		tab.MatrixB.Resize(tab.MatrixA.Rows, tab.MatrixA.Cols)
+		tab.Rows.Set(tab.MatrixA.Rows)
+		tab.Cols.Set(tab.MatrixA.Cols)
+		tab.TableA.Refresh()
+		tab.TableB.Refresh()
+	})
+	tab.ActionsImportA = widget.NewButtonWithIcon(
+		"Import Matrix A",
+		theme.UploadIcon(),
+		func() {
+			tab.ActionsImportADialog.Show()
+		},
+	)
+
+	tab.ActionsImportBDialog = newMatrixOpenDialog(tab.GUI.Window, func(data [][]float64) {
+		tab.MatrixB, _ = cmatrix.NewMatrix(data, false)
+		tab.MatrixA.Resize(tab.MatrixB.Rows, tab.MatrixB.Cols)
+		tab.Rows.Set(tab.MatrixB.Rows)
+		tab.Cols.Set(tab.MatrixB.Cols)
+		tab.TableA.Refresh()
+		tab.TableB.Refresh()
+	})
+	tab.ActionsImportB = widget.NewButtonWithIcon(
+		"Import Matrix B",
+		theme.UploadIcon(),
+		func() {
+			tab.ActionsImportBDialog.Show()
+		},
+	)
+
+	tab.Report = widget.NewLabel("")
+	tab.Report.TextStyle.Monospace = true
+	tab.ReportContainer = container.NewPadded(container.NewBorder(
+		container.NewCenter(widget.NewLabelWithStyle(
+			"Report", fyne.TextAlignCenter, fyne.TextStyle{Bold: true})),
+		nil, nil, nil, container.NewScroll(tab.Report)))
+
+	tab.ActionsCalculate = widget.NewButtonWithIcon(
+		"Calculate",
+		theme.GridIcon(),
+		func() {
+			result, report, err := tab.calculate()
+			if err != nil {
+				dialog.ShowInformation("Error!", err.Error(), tab.GUI.Window)
+				return
+			}
+
+			if result == nil {
+				tab.MatrixResult, _ = cmatrix.NewBlankMatrix(1, 1, false)
+			} else {
+				tab.MatrixResult, _ = cmatrix.NewMatrix(result, false)
+			}
+			tab.TableResult.Refresh()
+			tab.Report.SetText(report)
+		},
+	)
+
+	tab.ActionsContainer = container.NewPadded(container.NewVBox(
+		createLabeledEntry("Rows: ", tab.ActionsRows),
+		createLabeledEntry("Cols: ", tab.ActionsCols),
+		container.NewPadded(tab.ActionsOperation),
+		container.NewPadded(tab.ActionsVectors),
+		container.NewPadded(tab.ActionsImportA),
+		container.NewPadded(tab.ActionsImportB),
+		container.NewPadded(tab.ActionsCalculate),
+	))
+
+	tab.MainContainer = container.NewBorder(
+		nil, nil, tab.ActionsContainer, nil,
+		container.NewAdaptiveGrid(2,
+			tab.TableAContainer,
+			tab.TableBContainer,
+			tab.TableResultContainer,
+			tab.ReportContainer,
+		),
+	)
+
+	return tab
+}
+
+// returns matrix for the result table (nil if there is none) and text report
+func (p *SubspacesTab) calculate() ([][]float64, string, error) {
+	rank := p.MatrixA.Rank()
+	report := fmt.Sprintf("rank: %d\n", rank)
+
+	switch p.ActionsOperation.Selected {
+	case subspaceRREF:
+		return p.MatrixA.RREF(), report, nil
+	case subspaceNull:
+		basis := p.MatrixA.NullSpace()
+		return basis, report + fmt.Sprintf("dimension: %d\n", p.MatrixA.Cols-rank), nil
+	case subspaceColumn:
+		return p.MatrixA.ColumnSpace(), report + fmt.Sprintf("dimension: %d\n", rank), nil
+	case subspaceRow:
+		return p.MatrixA.RowSpace(), report + fmt.Sprintf("dimension: %d\n", rank), nil
+	case subspaceLeftNull:
+		basis := p.MatrixA.LeftNullSpace()
+		return basis, report + fmt.Sprintf("dimension: %d\n", p.MatrixA.Rows-rank), nil
+	case subspaceIndependent:
+		if p.MatrixA.Independent(p.ActionsVectors.Selected == vectorsRows) {
+			return nil, report + "vectors are linearly independent\n", nil
+		}
+		return nil, report + "vectors are linearly dependent\n", nil
+	case subspaceTransition:
+		transition, err := p.MatrixA.TransitionMatrix(p.MatrixB)
+		if err != nil {
+			return nil, "", err
+		}
+		return transition, report, nil
+	default:
+		return nil, "", errors.New("operation is not selected")
+	}
+}
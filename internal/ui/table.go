@@ -0,0 +1,164 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+
+	cmatrix "github.com/shimeoki/mlat/internal/matrix"
+	"github.com/shimeoki/mlat/internal/symbolic"
+)
+
+// values shown in the table created by createCellTable
+type cellSource interface {
+	// returns 0, 0 if there is no matrix
+	size() (rows, cols int)
+	augmented() bool
+	get(row, col int) string
+	// text that can not be parsed is ignored
+	set(row, col int, text string)
+}
+
+type realCells struct {
+	matrix **cmatrix.Matrix
+}
+
+func (p realCells) size() (int, int) {
+	if *p.matrix == nil {
+		return 0, 0
+	}
+	return (*p.matrix).Rows, (*p.matrix).Cols
+}
+
+func (p realCells) augmented() bool {
+	return *p.matrix != nil && (*p.matrix).Augmented
+}
+
+func (p realCells) get(row, col int) string {
+	return fmt.Sprintf("%v", (*p.matrix).Data[row][col])
+}
+
+func (p realCells) set(row, col int, text string) {
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return
+	}
+	(*p.matrix).Data[row][col] = value
+}
+
+type complexCells struct {
+	matrix **cmatrix.ComplexMatrix
+}
+
+func (p complexCells) size() (int, int) {
+	if *p.matrix == nil {
+		return 0, 0
+	}
+	return (*p.matrix).Rows, (*p.matrix).Cols
+}
+
+func (p complexCells) augmented() bool {
+	return *p.matrix != nil && (*p.matrix).Augmented
+}
+
+func (p complexCells) get(row, col int) string {
+	return cmatrix.FormatNumber((*p.matrix).Data[row][col])
+}
+
+func (p complexCells) set(row, col int, text string) {
+	value, err := cmatrix.ParseComplex(text)
+	if err != nil {
+		return
+	}
+	(*p.matrix).Data[row][col] = value
+}
+
+func createComplexTable(matrix **cmatrix.ComplexMatrix) *widget.Table {
+	return createCellTable(complexCells{matrix})
+}
+
+type symbolicCells struct {
+	matrix **symbolic.Matrix
+}
+
+func (p symbolicCells) size() (int, int) {
+	if *p.matrix == nil {
+		return 0, 0
+	}
+	return (*p.matrix).Rows, (*p.matrix).Cols
+}
+
+func (p symbolicCells) augmented() bool {
+	return *p.matrix != nil && (*p.matrix).Augmented
+}
+
+func (p symbolicCells) get(row, col int) string {
+	return (*p.matrix).Data[row][col].String()
+}
+
+func (p symbolicCells) set(row, col int, text string) {
+	value, err := symbolic.Parse(text)
+	if err != nil {
+		return
+	}
+	(*p.matrix).Data[row][col] = value
+}
+
+func createSymbolicTable(matrix **symbolic.Matrix) *widget.Table {
+	return createCellTable(symbolicCells{matrix})
+}
+
+func createCellTable(source cellSource) (table *widget.Table) {
+	table = widget.NewTableWithHeaders(
+		source.size,
+		func() fyne.CanvasObject {
+			cell := widget.NewEntry()
+			cell.Resize(fyne.NewSize(40, 20))
+			return cell
+		},
+		func(cellID widget.TableCellID, cell fyne.CanvasObject) {
+			cellEntry := cell.(*widget.Entry)
+			// cells are reused by the table, so the callback is rebound to the current cell
+			cellEntry.OnChanged = nil
+			rows, cols := source.size()
+			if cellID.Row >= rows || cellID.Col >= cols {
+				cellEntry.SetText("nil")
+				return
+			}
+
+			cellEntry.SetText(source.get(cellID.Row, cellID.Col))
+			cellEntry.OnChanged = func(s string) {
+				rows, cols := source.size()
+				if cellID.Row >= rows || cellID.Col >= cols {
+					return
+				}
+
+				source.set(cellID.Row, cellID.Col, s)
+			}
+		},
+	)
+	table.CreateHeader = func() fyne.CanvasObject {
+		return newTableHeader()
+	}
+	table.UpdateHeader = func(cellID widget.TableCellID, cell fyne.CanvasObject) {
+		var text string
+
+		_, cols := source.size()
+		if cellID.Row == -1 && cellID.Col == -1 {
+			text = ""
+		} else if cellID.Row == -1 {
+			if cellID.Col+1 == cols && source.augmented() {
+				text = ""
+			} else {
+				text = fmt.Sprint(cellID.Col + 1)
+			}
+		} else {
+			text = fmt.Sprint(cellID.Row + 1)
+		}
+
+		cell.(*tableHeader).SetText(text)
+	}
+	return
+}
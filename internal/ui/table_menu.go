@@ -0,0 +1,212 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	cmatrix "github.com/shimeoki/mlat/internal/matrix"
+)
+
+// label that can show a context menu on secondary tap
+type tableHeader struct {
+	widget.Label
+
+	OnTappedSecondary func(*fyne.PointEvent)
+}
+
+func newTableHeader() *tableHeader {
+	header := &tableHeader{}
+	header.TextStyle.Bold = true
+	header.Alignment = fyne.TextAlignCenter
+	header.ExtendBaseWidget(header)
+	return header
+}
+
+func (p *tableHeader) TappedSecondary(event *fyne.PointEvent) {
+	if p.OnTappedSecondary != nil {
+		p.OnTappedSecondary(event)
+	}
+}
+
+// undo stack of matrix snapshots
+type matrixHistory struct {
+	snapshots []*cmatrix.Matrix
+}
+
+func (p *matrixHistory) Push(matrix *cmatrix.Matrix) {
+	p.snapshots = append(p.snapshots, matrix.Clone())
+}
+
+// returns nil if there is nothing to undo
+func (p *matrixHistory) Pop() *cmatrix.Matrix {
+	if len(p.snapshots) == 0 {
+		return nil
+	}
+
+	matrix := p.snapshots[len(p.snapshots)-1]
+	p.snapshots = p.snapshots[:len(p.snapshots)-1]
+	return matrix
+}
+
+func (p *matrixHistory) Empty() bool {
+	return len(p.snapshots) == 0
+}
+
+func (p *matrixHistory) Clear() {
+	p.snapshots = nil
+}
+
+// adds context menus with row and column operations to the table headers.
+// every operation is saved in history before it is applied. onChange is called after
+// the matrix was changed or restored
+func attachTableMenu(
+	table *widget.Table,
+	matrix **cmatrix.Matrix,
+	history *matrixHistory,
+	window fyne.Window,
+	onChange func(),
+) {
+	apply := func(operation func(m *cmatrix.Matrix) error) {
+		history.Push(*matrix)
+		if err := operation(*matrix); err != nil {
+			history.Pop()
+			dialog.ShowInformation("Error!", err.Error(), window)
+			return
+		}
+
+		onChange()
+	}
+
+	undo := fyne.NewMenuItem("Undo", func() {
+		if previous := history.Pop(); previous != nil {
+			*matrix = previous
+			onChange()
+		}
+	})
+
+	update := table.UpdateHeader
+	table.UpdateHeader = func(cellID widget.TableCellID, cell fyne.CanvasObject) {
+		update(cellID, cell)
+
+		header := cell.(*tableHeader)
+		header.OnTappedSecondary = func(event *fyne.PointEvent) {
+			if *matrix == nil || (cellID.Row == -1 && cellID.Col == -1) {
+				return
+			}
+
+			undo.Disabled = history.Empty()
+
+			var menu *fyne.Menu
+			if cellID.Col == -1 {
+				menu = rowMenu(cellID.Row, apply, window)
+			} else {
+				menu = colMenu(cellID.Col, apply, window)
+			}
+			menu.Items = append(menu.Items, fyne.NewMenuItemSeparator(), undo)
+
+			widget.ShowPopUpMenuAtPosition(
+				menu, window.Canvas(), event.AbsolutePosition,
+			)
+		}
+	}
+}
+
+func rowMenu(row int, apply func(func(*cmatrix.Matrix) error), window fyne.Window) *fyne.Menu {
+	return fyne.NewMenu(fmt.Sprintf("Row %d", row+1),
+		fyne.NewMenuItem("Insert row above", func() {
+			apply(func(m *cmatrix.Matrix) error {
+				m.AddRow(row)
+				return nil
+			})
+		}),
+		fyne.NewMenuItem("Delete row", func() {
+			apply(func(m *cmatrix.Matrix) error {
+				return m.DeleteRow(row)
+			})
+		}),
+		fyne.NewMenuItem("Swap with row...", func() {
+			other := widget.NewEntry()
+			showOperationForm("Swap rows", window, func() {
+				index, err := strconv.Atoi(other.Text)
+				apply(func(m *cmatrix.Matrix) error {
+					if err != nil {
+						return err
+					}
+					return m.SwapRows(row, index-1)
+				})
+			}, widget.NewFormItem("Row", other))
+		}),
+		fyne.NewMenuItem("Scale row...", func() {
+			factor := widget.NewEntry()
+			showOperationForm("Scale row", window, func() {
+				scalar, err := strconv.ParseFloat(factor.Text, 64)
+				apply(func(m *cmatrix.Matrix) error {
+					if err != nil {
+						return err
+					}
+					return m.ScaleRow(row, scalar)
+				})
+			}, widget.NewFormItem("Factor", factor))
+		}),
+		fyne.NewMenuItem("Add multiple of row...", func() {
+			other := widget.NewEntry()
+			factor := widget.NewEntry()
+			showOperationForm("Add multiple of row", window, func() {
+				index, err := strconv.Atoi(other.Text)
+				scalar, errScalar := strconv.ParseFloat(factor.Text, 64)
+				apply(func(m *cmatrix.Matrix) error {
+					if err != nil {
+						return err
+					}
+					if errScalar != nil {
+						return errScalar
+					}
+					return m.AddRowMultiple(row, index-1, scalar)
+				})
+			}, widget.NewFormItem("Row", other), widget.NewFormItem("Factor", factor))
+		}),
+	)
+}
+
+func colMenu(col int, apply func(func(*cmatrix.Matrix) error), window fyne.Window) *fyne.Menu {
+	return fyne.NewMenu(fmt.Sprintf("Column %d", col+1),
+		fyne.NewMenuItem("Insert column before", func() {
+			apply(func(m *cmatrix.Matrix) error {
+				m.AddCol(col)
+				return nil
+			})
+		}),
+		fyne.NewMenuItem("Delete column", func() {
+			apply(func(m *cmatrix.Matrix) error {
+				return m.DeleteCol(col)
+			})
+		}),
+		fyne.NewMenuItem("Swap with column...", func() {
+			other := widget.NewEntry()
+			showOperationForm("Swap columns", window, func() {
+				index, err := strconv.Atoi(other.Text)
+				apply(func(m *cmatrix.Matrix) error {
+					if err != nil {
+						return err
+					}
+					return m.SwapCols(col, index-1)
+				})
+			}, widget.NewFormItem("Column", other))
+		}),
+	)
+}
+
+func showOperationForm(title string, window fyne.Window, onConfirm func(), items ...*widget.FormItem) {
+	dialog.ShowForm(title, "Apply", "Cancel", items,
+		func(confirmed bool) {
+			if confirmed {
+				onConfirm()
+			}
+		},
+		window,
+	)
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"fyne.io/fyne/v2"
@@ -16,6 +17,7 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	cmatrix "github.com/shimeoki/mlat/internal/matrix"
+	"github.com/shimeoki/mlat/internal/symbolic"
 )
 
 type GUI struct {
@@ -25,14 +27,37 @@ type GUI struct {
 	App    fyne.App
 }
 
+const (
+	solutionDeterminant = "Calculate determinant(s)"
+	solutionPermanent   = "Calculate permanent"
+	solutionPfaffian    = "Calculate pfaffian"
+	solutionDiophantine = "Solve in integers"
+	solutionParameter   = "Analyze parameter"
+	solutionJordan      = "Jordan normal form"
+)
+
+const (
+	unknownResult = "Find Result = A·B"
+	unknownA      = "Find A from X·B = Result"
+	unknownB      = "Find B from A·X = Result"
+	unknownX      = "Find X from A·X·B = Result"
+)
+
 type DeterminantTab struct {
 	TableContainer *fyne.Container
 	Table          *widget.Table
 	Matrix         *cmatrix.Matrix
+	ComplexTable   *widget.Table
+	ComplexMatrix  *cmatrix.ComplexMatrix
+	SymbolicTable  *widget.Table
+	SymbolicMatrix *symbolic.Matrix
 
 	OptionsContainer *fyne.Container
 	OptionsLabel     *canvas.Text
 	OptionsAugmented *widget.Check
+	OptionsComplex   *widget.Check
+	OptionsSymbolic  *widget.Check
+	OptionsModulus   *widget.Entry
 	OptionsRows      *widget.Entry
 	OptionsCols      *widget.Entry
 	OptionsSolution  *widget.Select
@@ -44,9 +69,12 @@ type DeterminantTab struct {
 	ActionsExportDialog *dialog.FileDialog
 	ActionsCalculate    *widget.Button
 	ActionsCopy         *widget.Button
+	ActionsUndo         *widget.Button
 	ActionsAnswer       *widget.Entry
 	ActionsStatus       *widget.ProgressBarInfinite
 
+	History *matrixHistory
+
 	MainContainer *fyne.Container
 
 	GUI *GUI
@@ -78,6 +106,9 @@ type MultiplyTab struct {
 	ActionsCols          *widget.Entry
 	ActionsColsContainer *fyne.Container
 
+	ActionsUnknown          *widget.Select
+	ActionsUnknownContainer *fyne.Container
+
 	ActionsOptions *fyne.Container
 
 	ActionsImportA          *widget.Button
@@ -99,63 +130,7 @@ type MultiplyTab struct {
 }
 
 func createTable(matrix **cmatrix.Matrix) (table *widget.Table) {
-	table = widget.NewTableWithHeaders(
-		func() (int, int) {
-			if *matrix == nil {
-				return 0, 0
-			}
-			return (*matrix).Rows, (*matrix).Cols
-		},
-		func() fyne.CanvasObject {
-			cell := widget.NewEntry()
-			cell.Resize(fyne.NewSize(40, 20))
-			return cell
-		},
-		func(cellID widget.TableCellID, cell fyne.CanvasObject) {
-			cellEntry := cell.(*widget.Entry)
-			var text string
-			if *matrix == nil {
-				text = "nil"
-			} else {
-				if cellEntry.OnChanged == nil {
-					cellEntry.OnChanged = func(s string) {
-						value, err := strconv.ParseFloat(s, 64)
-						if err != nil {
-							return
-						}
-
-						(*matrix).Data[cellID.Row][cellID.Col] = value
-					}
-				}
-				text = fmt.Sprintf("%v", (*matrix).Data[cellID.Row][cellID.Col])
-			}
-			cellEntry.SetText(text)
-		},
-	)
-	table.CreateHeader = func() fyne.CanvasObject {
-		header := widget.NewLabel("header")
-		header.TextStyle.Bold = true
-		header.Alignment = fyne.TextAlignCenter
-		return header
-	}
-	table.UpdateHeader = func(cellID widget.TableCellID, cell fyne.CanvasObject) {
-		var text string
-
-		if cellID.Row == -1 && cellID.Col == -1 {
-			text = ""
-		} else if cellID.Row == -1 {
-			if cellID.Col+1 == (*matrix).Cols && (*matrix).Augmented {
-				text = ""
-			} else {
-				text = fmt.Sprint(cellID.Col + 1)
-			}
-		} else {
-			text = fmt.Sprint(cellID.Row + 1)
-		}
-
-		cell.(*widget.Label).SetText(text)
-	}
-	return
+	return createCellTable(realCells{matrix})
 }
 
 func NewGUI() *GUI {
@@ -166,9 +141,25 @@ func NewGUI() *GUI {
 
 	determinantTab := gui.newDeterminantTab()
 	multiplyTab := gui.newMultiplyTab()
+	operationsTab := gui.newOperationsTab()
+	calculatorTab := gui.newCalculatorTab()
+	closureTab := gui.newClosureTab()
+	relationTab := gui.newRelationTab()
+	graphTab := gui.newGraphTab()
+	orthogonalTab := gui.newOrthogonalTab()
+	subspacesTab := gui.newSubspacesTab()
+	quadraticTab := gui.newQuadraticTab()
 	gui.Tabs = container.NewAppTabs(
 		container.NewTabItem("Determinant", determinantTab.MainContainer),
 		container.NewTabItem("Multiply", multiplyTab.MainContainer),
+		container.NewTabItem("Operations", operationsTab.MainContainer),
+		container.NewTabItem("Calculator", calculatorTab.MainContainer),
+		container.NewTabItem("Closure", closureTab.MainContainer),
+		container.NewTabItem("Relation", relationTab.MainContainer),
+		container.NewTabItem("Graph", graphTab.MainContainer),
+		container.NewTabItem("Orthogonal", orthogonalTab.MainContainer),
+		container.NewTabItem("Subspaces", subspacesTab.MainContainer),
+		container.NewTabItem("Quadratic", quadraticTab.MainContainer),
 	)
 
 	gui.Window.SetContent(gui.Tabs)
@@ -187,10 +178,15 @@ func (p *GUI) newDeterminantTab() *DeterminantTab {
 
 	tab.Matrix = nil
 
+	tab.History = &matrixHistory{}
+
 	tab.Table = createTable(&tab.Matrix)
+	tab.ComplexTable = createComplexTable(&tab.ComplexMatrix)
+	tab.SymbolicTable = createSymbolicTable(&tab.SymbolicMatrix)
 	tab.TableContainer = container.NewPadded(tab.Table)
 	tab.createOptions()
 	tab.createActions()
+	attachTableMenu(tab.Table, &tab.Matrix, tab.History, p.Window, tab.matrixChanged)
 	tab.MainContainer = container.NewBorder(
 		nil, tab.ActionsContainer, tab.OptionsContainer, nil, tab.TableContainer,
 	)
@@ -301,26 +297,14 @@ func (p *GUI) newMultiplyTab() *MultiplyTab {
 			widget.NewLabelWithStyle("Cols: ", fyne.TextAlignCenter, fyne.TextStyle{Monospace: true}))),
 		container.NewPadded(tab.ActionsCols))
 
-	tab.ActionsImportADialog = dialog.NewFileOpen(
-		func(uri fyne.URIReadCloser, err error) {
-			if uri == nil || err != nil {
-				return
-			}
-
-			matrix, err := cmatrix.ReadSlow(uri.URI().Path())
-			if err != nil {
-				return
-			}
-
-			tab.MatrixA, _ = cmatrix.NewMatrix(matrix, false)
-			tab.Rows.Set(tab.MatrixA.Rows)
-			tab.Common.Set(tab.MatrixA.Cols)
-			tab.TableA.Refresh()
-			tab.TableB.Refresh()
-			tab.TableResult.Refresh()
-		},
-		tab.GUI.Window,
-	)
+	tab.ActionsImportADialog = newMatrixOpenDialog(tab.GUI.Window, func(matrix [][]float64) {
+		tab.MatrixA, _ = cmatrix.NewMatrix(matrix, false)
+		tab.Rows.Set(tab.MatrixA.Rows)
+		tab.Common.Set(tab.MatrixA.Cols)
+		tab.TableA.Refresh()
+		tab.TableB.Refresh()
+		tab.TableResult.Refresh()
+	})
 	tab.ActionsImportA = widget.NewButtonWithIcon(
 		"Import Matrix A",
 		theme.UploadIcon(),
@@ -330,26 +314,14 @@ func (p *GUI) newMultiplyTab() *MultiplyTab {
 	)
 	tab.ActionsImportAContainer = container.NewPadded(tab.ActionsImportA)
 
-	tab.ActionsImportBDialog = dialog.NewFileOpen(
-		func(uri fyne.URIReadCloser, err error) {
-			if uri == nil || err != nil {
-				return
-			}
-
-			matrix, err := cmatrix.ReadSlow(uri.URI().Path())
-			if err != nil {
-				return
-			}
-
-			tab.MatrixB, _ = cmatrix.NewMatrix(matrix, false)
-			tab.Common.Set(tab.MatrixB.Rows)
-			tab.Cols.Set(tab.MatrixB.Cols)
-			tab.TableA.Refresh()
-			tab.TableB.Refresh()
-			tab.TableResult.Refresh()
-		},
-		tab.GUI.Window,
-	)
+	tab.ActionsImportBDialog = newMatrixOpenDialog(tab.GUI.Window, func(matrix [][]float64) {
+		tab.MatrixB, _ = cmatrix.NewMatrix(matrix, false)
+		tab.Common.Set(tab.MatrixB.Rows)
+		tab.Cols.Set(tab.MatrixB.Cols)
+		tab.TableA.Refresh()
+		tab.TableB.Refresh()
+		tab.TableResult.Refresh()
+	})
 	tab.ActionsImportB = widget.NewButtonWithIcon(
 		"Import Matrix B",
 		theme.UploadIcon(),
@@ -363,22 +335,32 @@ func (p *GUI) newMultiplyTab() *MultiplyTab {
 		"Calculate",
 		theme.GridIcon(),
 		func() {
-			matrixResultData := tab.MatrixA.Multiply(tab.MatrixB)
-			if matrixResultData == nil {
-				return
+			err := tab.calculate()
+			if err != nil {
+				dialog.ShowInformation("Error!", err.Error(), tab.GUI.Window)
 			}
-
-			tab.MatrixResult, _ = cmatrix.NewMatrix(matrixResultData, false)
-			tab.TableResult.Refresh()
 		},
 	)
 	tab.ActionsCalculateContainer = container.NewPadded(tab.ActionsCalculate)
 
+	tab.ActionsUnknown = widget.NewSelect(
+		[]string{
+			unknownResult,
+			unknownA,
+			unknownB,
+			unknownX,
+		},
+		nil,
+	)
+	tab.ActionsUnknown.SetSelectedIndex(0)
+	tab.ActionsUnknownContainer = container.NewPadded(tab.ActionsUnknown)
+
 	tab.ActionsOptions = container.NewHBox(
 		container.NewVBox(
 			tab.ActionsCommonContainer,
 			tab.ActionsRowsContainer,
 			tab.ActionsColsContainer,
+			tab.ActionsUnknownContainer,
 		), container.NewVBox(
 			tab.ActionsImportAContainer,
 			tab.ActionsImportBContainer,
@@ -401,6 +383,48 @@ func (p *GUI) newMultiplyTab() *MultiplyTab {
 	return tab
 }
 
+// fills the unknown matrix selected in ActionsUnknown
+func (p *MultiplyTab) calculate() error {
+	switch p.ActionsUnknown.Selected {
+	case unknownA:
+		x, err := p.MatrixB.SolveRight(p.MatrixResult)
+		if err != nil {
+			return err
+		}
+
+		p.MatrixA = x
+		p.TableA.Refresh()
+	case unknownB:
+		x, err := p.MatrixA.SolveLeft(p.MatrixResult)
+		if err != nil {
+			return err
+		}
+
+		p.MatrixB = x
+		p.TableB.Refresh()
+	case unknownX:
+		// X has no table, A and B are square and X has the size of Result
+		x, err := p.MatrixA.SolveSandwich(p.MatrixB, p.MatrixResult)
+		if err != nil {
+			return err
+		}
+
+		result := dialog.NewCustom("X", "Close", createTable(&x), p.GUI.Window)
+		result.Resize(fyne.NewSize(400, 300))
+		result.Show()
+	default:
+		data := p.MatrixA.Multiply(p.MatrixB)
+		if data == nil {
+			return nil
+		}
+
+		p.MatrixResult, _ = cmatrix.NewMatrix(data, false)
+		p.TableResult.Refresh()
+	}
+
+	return nil
+}
+
 func (p *DeterminantTab) createOptions() {
 	p.OptionsContainer = container.NewVBox()
 
@@ -412,6 +436,22 @@ func (p *DeterminantTab) createOptions() {
 	p.OptionsAugmented = widget.NewCheck(
 		"Augmented",
 		func(state bool) {
+			if p.OptionsComplex.Checked {
+				if p.ComplexMatrix != nil {
+					p.ComplexMatrix, _ = cmatrix.NewComplexMatrix(p.ComplexMatrix.Data, state)
+				}
+				p.ComplexTable.Refresh()
+				return
+			}
+
+			if p.OptionsSymbolic.Checked {
+				if p.SymbolicMatrix != nil {
+					p.SymbolicMatrix, _ = symbolic.NewMatrix(p.SymbolicMatrix.Data, state)
+				}
+				p.SymbolicTable.Refresh()
+				return
+			}
+
 			if p.Matrix == nil {
 				return
 			}
@@ -423,6 +463,72 @@ func (p *DeterminantTab) createOptions() {
 	// p.OptionsAugmented.Disable()
 	p.OptionsContainer.Add(p.OptionsAugmented)
 
+	p.OptionsComplex = widget.NewCheck(
+		"Complex",
+		func(state bool) {
+			if state {
+				if p.OptionsSymbolic.Checked && p.hasVariables() {
+					p.OptionsComplex.Checked = false
+					p.OptionsComplex.Refresh()
+					dialog.ShowInformation("Error!", "matrix has variables, replace them with numbers first", p.GUI.Window)
+					return
+				}
+
+				p.OptionsSymbolic.SetChecked(false)
+				if p.Matrix != nil {
+					p.ComplexMatrix, _ = cmatrix.NewComplexMatrixFromReal(p.Matrix)
+				}
+			} else if p.ComplexMatrix != nil {
+				if !p.ComplexMatrix.IsReal() {
+					// imaginary parts would be dropped, keep complex values
+					p.OptionsComplex.Checked = true
+					p.OptionsComplex.Refresh()
+					dialog.ShowInformation("Error!", "matrix has imaginary parts, set them to zero first", p.GUI.Window)
+					return
+				}
+
+				p.Matrix, _ = cmatrix.NewMatrix(p.ComplexMatrix.Real(), p.ComplexMatrix.Augmented)
+				p.History.Clear()
+			}
+
+			p.showTable()
+		},
+	)
+	p.OptionsContainer.Add(p.OptionsComplex)
+
+	p.OptionsSymbolic = widget.NewCheck(
+		"Symbolic",
+		func(state bool) {
+			if state {
+				if p.OptionsComplex.Checked && p.ComplexMatrix != nil && !p.ComplexMatrix.IsReal() {
+					p.OptionsSymbolic.Checked = false
+					p.OptionsSymbolic.Refresh()
+					dialog.ShowInformation("Error!", "matrix has imaginary parts, set them to zero first", p.GUI.Window)
+					return
+				}
+
+				p.OptionsComplex.SetChecked(false)
+				if p.Matrix != nil {
+					p.SymbolicMatrix, _ = symbolic.NewMatrixFromReal(p.Matrix.Data, p.Matrix.Augmented)
+				}
+			} else if p.SymbolicMatrix != nil {
+				if p.hasVariables() {
+					// variables would become zeros, keep symbolic values
+					p.OptionsSymbolic.Checked = true
+					p.OptionsSymbolic.Refresh()
+					dialog.ShowInformation("Error!", "matrix has variables, replace them with numbers first", p.GUI.Window)
+					return
+				}
+
+				p.Matrix, _ = cmatrix.NewMatrix(p.SymbolicMatrix.Real(), p.SymbolicMatrix.Augmented)
+				p.History.Clear()
+			}
+
+			p.showTable()
+		},
+	)
+	p.OptionsContainer.Add(p.OptionsSymbolic)
+
 	validator := func(s string) error {
 		_, err := strconv.ParseInt(s, 10, 64)
 		return err
@@ -440,12 +546,38 @@ func (p *DeterminantTab) createOptions() {
 	p.OptionsCols.Disable()
 	p.OptionsContainer.Add(p.OptionsCols)
 
+	p.OptionsModulus = widget.NewEntry()
+	p.OptionsModulus.SetPlaceHolder("Modulus...")
+	p.OptionsModulus.Validator = func(s string) error {
+		if s == "" {
+			return nil
+		}
+
+		modulus, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return err
+		}
+
+		if modulus < 2 {
+			return errors.New("error: modulus should be at least 2")
+		}
+
+		return nil
+	}
+	p.OptionsContainer.Add(p.OptionsModulus)
+
 	p.OptionsSolution = widget.NewSelect(
-		[]string{"Calculate determinant(s)"},
+		[]string{
+			solutionDeterminant,
+			solutionPermanent,
+			solutionPfaffian,
+			solutionDiophantine,
+			solutionParameter,
+			solutionJordan,
+		},
 		func(string) {},
 	)
 	p.OptionsSolution.SetSelectedIndex(0)
-	p.OptionsSolution.Disable()
 	p.OptionsContainer.Add(p.OptionsSolution)
 
 	p.OptionsContainer = container.NewPadded(p.OptionsContainer)
@@ -459,16 +591,51 @@ func (p *DeterminantTab) createActions() {
 			if uri == nil || err != nil {
 				return
 			}
+			defer uri.Close()
+
+			name := uri.URI().Name()
+			if p.OptionsComplex.Checked && !cmatrix.IsRealFormat(name) {
+				mx, err := cmatrix.ReadComplexFile(name, uri)
+				if err != nil {
+					dialog.ShowInformation("Error!", err.Error(), p.GUI.Window)
+					return
+				}
 
-			mx, err := cmatrix.ReadSlow(uri.URI().Path())
-			if err != nil {
+				p.ComplexMatrix = mx
+				p.showAugmented(mx.Augmented)
+				p.matrixChanged()
 				return
 			}
 
-			p.Matrix, _ = cmatrix.NewMatrix(mx, false)
-			p.OptionsRows.SetText(fmt.Sprint(p.Matrix.Rows))
-			p.OptionsCols.SetText(fmt.Sprint(p.Matrix.Cols))
-			p.Table.Refresh()
+			_, table := cmatrix.CSVDelimiter(uri.URI().Extension())
+			if p.OptionsSymbolic.Checked && !table && !cmatrix.IsRealFormat(name) {
+				cells, augmented, err := cmatrix.ReadCells(uri)
+				if err == nil {
+					p.SymbolicMatrix, err = symbolic.ParseMatrix(cells, augmented)
+				}
+				if err != nil {
+					dialog.ShowInformation("Error!", err.Error(), p.GUI.Window)
+					return
+				}
+
+				p.showAugmented(augmented)
+				p.matrixChanged()
+				return
+			}
+
+			readMatrixFile(p.GUI.Window, uri, func(mx *cmatrix.Matrix) {
+				switch {
+				case p.OptionsComplex.Checked:
+					p.ComplexMatrix, _ = cmatrix.NewComplexMatrixFromReal(mx)
+				case p.OptionsSymbolic.Checked:
+					p.SymbolicMatrix, _ = symbolic.NewMatrixFromReal(mx.Data, mx.Augmented)
+				default:
+					p.Matrix = mx
+					p.History.Clear()
+				}
+				p.showAugmented(mx.Augmented)
+				p.matrixChanged()
+			})
 		},
 		p.GUI.Window,
 	)
@@ -485,8 +652,25 @@ func (p *DeterminantTab) createActions() {
 			if uri == nil || err != nil {
 				return
 			}
+			defer uri.Close()
+
+			extension := strings.ToLower(uri.URI().Extension())
+			_, table := cmatrix.CSVDelimiter(extension)
+
+			switch {
+			case p.OptionsSymbolic.Checked && (table || cmatrix.IsRealFormat(uri.URI().Name())):
+				err = fmt.Errorf("symbolic matrices can be written only to text files, not to %s files", extension)
+			case p.OptionsSymbolic.Checked:
+				err = cmatrix.WriteCells(uri, p.SymbolicMatrix.Cells(), p.SymbolicMatrix.Augmented)
+			case p.OptionsComplex.Checked:
+				err = cmatrix.WriteComplexFile(uri.URI().Name(), uri, p.ComplexMatrix)
+			default:
+				err = cmatrix.WriteFile(uri.URI().Name(), uri, p.Matrix)
+			}
 
-			cmatrix.Write(uri.URI().Path(), p.Matrix.Data)
+			if err != nil {
+				dialog.ShowInformation("Error!", err.Error(), p.GUI.Window)
+			}
 		},
 		p.GUI.Window,
 	)
@@ -494,6 +678,11 @@ func (p *DeterminantTab) createActions() {
 		"Export Matrix",
 		theme.DownloadIcon(),
 		func() {
+			if !p.hasMatrix() {
+				dialog.ShowInformation("Error!", "matrix is not imported", p.GUI.Window)
+				return
+			}
+
 			p.ActionsExportDialog.Show()
 		},
 	)
@@ -504,24 +693,18 @@ func (p *DeterminantTab) createActions() {
 		func() {
 			p.ActionsStatus.Start()
 			defer p.ActionsStatus.Stop()
-			answer, err := p.Matrix.Calculate()
+			answer, err := p.calculate()
 			if err != nil {
 				p.ActionsStatus.Stop()
 				dialog.ShowInformation(
 					"Error!",
-					"Matrix is not a square.",
+					err.Error(),
 					p.GUI.Window,
 				)
 				return
 			}
 
-			if len(answer) == 1 {
-				p.ActionsAnswer.SetText(fmt.Sprintf("%f", answer[0]))
-			} else {
-				p.ActionsAnswer.SetText(
-					cmatrix.ArrayToString(p.Matrix.GetRoots(), " "),
-				)
-			}
+			p.ActionsAnswer.SetText(answer)
 		},
 	)
 
@@ -545,14 +728,314 @@ func (p *DeterminantTab) createActions() {
 		},
 	)
 
+	p.ActionsUndo = widget.NewButtonWithIcon(
+		"Undo",
+		theme.ContentUndoIcon(),
+		func() {
+			if previous := p.History.Pop(); previous != nil {
+				p.Matrix = previous
+				p.matrixChanged()
+			}
+		},
+	)
+	p.ActionsUndo.Disable()
+
 	p.ActionsStatus = widget.NewProgressBarInfinite()
 	p.ActionsStatus.Stop()
 
 	p.ActionsContainer.Add(p.ActionsImport)
 	p.ActionsContainer.Add(p.ActionsExport)
 	p.ActionsContainer.Add(p.ActionsCalculate)
+	p.ActionsContainer.Add(p.ActionsUndo)
 	p.ActionsContainer.Add(p.ActionsCopy)
 	p.ActionsContainer.Add(p.ActionsAnswer)
 	p.ActionsContainer.Add(p.ActionsStatus)
 	p.ActionsContainer = container.NewPadded(p.ActionsContainer)
 }
+
+// reports whether the matrix of the selected kind exists
+func (p *DeterminantTab) hasMatrix() bool {
+	switch {
+	case p.OptionsSymbolic.Checked:
+		return p.SymbolicMatrix != nil
+	case p.OptionsComplex.Checked:
+		return p.ComplexMatrix != nil
+	default:
+		return p.Matrix != nil
+	}
+}
+
+// sets the checkbox without its callback, imported matrix
+// already has the state with its name and precision
+func (p *DeterminantTab) showAugmented(state bool) {
+	p.OptionsAugmented.Checked = state
+	p.OptionsAugmented.Refresh()
+}
+
+// reports whether the symbolic matrix has cells that are not constant
+func (p *DeterminantTab) hasVariables() bool {
+	return p.SymbolicMatrix != nil && len(p.SymbolicMatrix.Variables()) > 0
+}
+
+func (p *DeterminantTab) calculate() (string, error) {
+	switch p.OptionsSolution.Selected {
+	case solutionPermanent, solutionPfaffian:
+		return p.calculateFunction()
+	case solutionDiophantine:
+		return p.calculateDiophantine()
+	case solutionParameter:
+		return p.calculateParameter()
+	case solutionJordan:
+		return p.calculateJordan()
+	}
+
+	if p.OptionsModulus.Text != "" {
+		return p.calculateModular()
+	}
+
+	if p.OptionsSymbolic.Checked {
+		return p.calculateSymbolic()
+	}
+
+	if p.OptionsComplex.Checked {
+		if p.ComplexMatrix == nil {
+			return "", errors.New("matrix is not imported")
+		}
+
+		answer, err := p.ComplexMatrix.Calculate()
+		if err != nil {
+			return "", err
+		}
+
+		if len(answer) == 1 {
+			return cmatrix.FormatNumber(answer[0]), nil
+		}
+		return cmatrix.ArrayToString(p.ComplexMatrix.GetRoots(), " "), nil
+	}
+
+	if p.Matrix == nil {
+		return "", errors.New("matrix is not imported")
+	}
+
+	answer, err := p.Matrix.Calculate()
+	if err != nil {
+		return "", err
+	}
+
+	if len(answer) == 1 {
+		return fmt.Sprintf("%f", answer[0]), nil
+	}
+
+	roots := p.Matrix.GetRoots()
+	if roots != nil {
+		p.verifyRoots()
+	}
+	return cmatrix.ArrayToString(roots, " "), nil
+}
+
+// warns if calculated roots do not satisfy the equations
+func (p *DeterminantTab) verifyRoots() {
+	verification, err := p.Matrix.Verify(cmatrix.RootsTolerance)
+	if err != nil || verification.Passed {
+		return
+	}
+
+	dialog.ShowInformation(
+		"Warning!",
+		fmt.Sprintf(
+			"Roots may be inaccurate: max residual is %g.\nResiduals: %s",
+			verification.Max,
+			cmatrix.ArrayToString(verification.Residuals, " "),
+		),
+		p.GUI.Window,
+	)
+}
+
+// returns matrix for the modes that work only with real values
+func (p *DeterminantTab) realMatrix() (*cmatrix.Matrix, error) {
+	if p.OptionsComplex.Checked || p.OptionsSymbolic.Checked || p.OptionsModulus.Text != "" {
+		return nil, errors.New("only real matrices are supported")
+	}
+
+	if p.Matrix == nil {
+		return nil, errors.New("matrix is not imported")
+	}
+
+	return p.Matrix, nil
+}
+
+// calculates determinant-like function selected in OptionsSolution
+func (p *DeterminantTab) calculateFunction() (string, error) {
+	matrix, err := p.realMatrix()
+	if err != nil {
+		return "", err
+	}
+
+	var answer float64
+	if p.OptionsSolution.Selected == solutionPermanent {
+		answer, err = matrix.Permanent()
+	} else {
+		answer, err = matrix.Pfaffian()
+	}
+
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%f", answer), nil
+}
+
+// solves augmented matrix in integers
+func (p *DeterminantTab) calculateDiophantine() (string, error) {
+	matrix, err := p.realMatrix()
+	if err != nil {
+		return "", err
+	}
+
+	integers, err := cmatrix.NewIntegerMatrix(matrix.Data, matrix.Augmented)
+	if err != nil {
+		return "", err
+	}
+
+	solution, err := integers.SolveDiophantine()
+	if err != nil {
+		return "", err
+	}
+	return solution.String(), nil
+}
+
+// finds jordan normal form with exact rationals
+func (p *DeterminantTab) calculateJordan() (string, error) {
+	matrix, err := p.realMatrix()
+	if err != nil {
+		return "", err
+	}
+
+	form, err := matrix.Jordan()
+	if err != nil {
+		return "", err
+	}
+	return form.String(), nil
+}
+
+// calculates determinant or roots of the system with variables in cells
+func (p *DeterminantTab) calculateSymbolic() (string, error) {
+	if p.SymbolicMatrix == nil {
+		return "", errors.New("matrix is not imported")
+	}
+
+	if !p.SymbolicMatrix.Augmented {
+		det, err := p.SymbolicMatrix.Determinant()
+		if err != nil {
+			return "", err
+		}
+		return det.String(), nil
+	}
+
+	roots, err := p.SymbolicMatrix.Solve()
+	if err != nil {
+		return "", err
+	}
+
+	values := make([]string, len(roots))
+	for i, root := range roots {
+		values[i] = root.String()
+	}
+	return strings.Join(values, " "), nil
+}
+
+// reports for which values of the parameter in cells the system
+// has a unique solution, infinitely many or none
+func (p *DeterminantTab) calculateParameter() (string, error) {
+	if !p.OptionsSymbolic.Checked {
+		return "", errors.New("parameter analysis needs symbolic values")
+	}
+
+	if p.SymbolicMatrix == nil {
+		return "", errors.New("matrix is not imported")
+	}
+
+	analysis, err := p.SymbolicMatrix.Analyze()
+	if err != nil {
+		return "", err
+	}
+
+	lines := strings.Split(strings.TrimSpace(analysis.String()), "\n")
+	return strings.Join(lines, "; "), nil
+}
+
+// calculates determinant or roots of the system modulo value from OptionsModulus
+func (p *DeterminantTab) calculateModular() (string, error) {
+	if p.OptionsComplex.Checked || p.OptionsSymbolic.Checked {
+		return "", errors.New("modulus cannot be used with complex or symbolic values")
+	}
+
+	if err := p.OptionsModulus.Validate(); err != nil {
+		return "", err
+	}
+
+	if p.Matrix == nil {
+		return "", errors.New("matrix is not imported")
+	}
+
+	modulus, _ := strconv.ParseInt(p.OptionsModulus.Text, 10, 64)
+	matrix, err := cmatrix.NewModularMatrix(p.Matrix.Data, modulus, p.Matrix.Augmented)
+	if err != nil {
+		return "", err
+	}
+
+	if !matrix.Augmented {
+		det, err := matrix.Determinant()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d (mod %d)", det, modulus), nil
+	}
+
+	roots, err := matrix.Solve()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s (mod %d)", cmatrix.ArrayToString(roots, " "), modulus), nil
+}
+
+// shows the table for the values selected in options
+func (p *DeterminantTab) showTable() {
+	table := p.Table
+	if p.OptionsComplex.Checked {
+		table = p.ComplexTable
+	} else if p.OptionsSymbolic.Checked {
+		table = p.SymbolicTable
+	}
+
+	p.TableContainer.Objects = []fyne.CanvasObject{table}
+	p.TableContainer.Refresh()
+	p.matrixChanged()
+}
+
+// updates widgets after the matrix was replaced or changed in place
+func (p *DeterminantTab) matrixChanged() {
+	rows, cols := 0, 0
+	switch {
+	case p.OptionsComplex.Checked:
+		if p.ComplexMatrix != nil {
+			rows, cols = p.ComplexMatrix.Rows, p.ComplexMatrix.Cols
+		}
+	case p.OptionsSymbolic.Checked:
+		if p.SymbolicMatrix != nil {
+			rows, cols = p.SymbolicMatrix.Rows, p.SymbolicMatrix.Cols
+		}
+	case p.Matrix != nil:
+		rows, cols = p.Matrix.Rows, p.Matrix.Cols
+	}
+
+	p.OptionsRows.SetText(fmt.Sprint(rows))
+	p.OptionsCols.SetText(fmt.Sprint(cols))
+	if p.History.Empty() {
+		p.ActionsUndo.Disable()
+	} else {
+		p.ActionsUndo.Enable()
+	}
+	p.Table.Refresh()
+	p.ComplexTable.Refresh()
+	p.SymbolicTable.Refresh()
+}